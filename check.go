@@ -1,24 +1,34 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Check (business logic)
-func Check(request CheckRequest, manager Github) (CheckResponse, error) {
+func Check(ctx context.Context, request CheckRequest, manager Github) (CheckResponse, error) {
 	var response CheckResponse
 
-	pulls, err := manager.ListOpenPullRequests()
+	pulls, err := manager.ListOpenPullRequests(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get last commits: %s", err)
 	}
 
 	disableSkipCI := request.Source.DisableCISkip
 
+	var debounce time.Duration
+	if request.Source.BaseChangeDebounce != "" {
+		debounce, err = time.ParseDuration(request.Source.BaseChangeDebounce)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse base change debounce: %s", err)
+		}
+	}
+
 Loop:
 	for _, p := range pulls {
 		// [ci skip]/[skip ci] in Pull request title
@@ -33,8 +43,18 @@ Loop:
 		if request.Source.BaseBranch != "" && p.PullRequestObject.BaseRefName != request.Source.BaseBranch {
 			continue
 		}
+		// Use the date of the latest commit on the base branch when it has moved past the PR (if enabled).
+		// The base has to stay put for the duration of the debounce window before it triggers a new version,
+		// which is dated at the end of the window so that it is newer than versions pushed during the window.
+		version := NewVersion(p)
+		if request.Source.TriggerOnBaseChange {
+			base := p.BaseTip.CommittedDate.Time
+			if ready := base.Add(debounce); base.After(version.CommittedDate) && !ready.After(time.Now()) {
+				version.CommittedDate = ready
+			}
+		}
 		// Filter out commits that are too old.
-		if !p.Tip.CommittedDate.Time.After(request.Version.CommittedDate) {
+		if !version.CommittedDate.After(request.Version.CommittedDate) {
 			continue
 		}
 
@@ -71,7 +91,7 @@ Loop:
 		var files []string
 
 		if len(request.Source.Paths) > 0 || len(request.Source.IgnorePaths) > 0 {
-			files, err = manager.ListModifiedFiles(p.Number)
+			files, err = manager.ListModifiedFiles(ctx, p.Number)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list modified files: %s", err)
 			}
@@ -105,7 +125,7 @@ Loop:
 				continue Loop
 			}
 		}
-		response = append(response, NewVersion(p))
+		response = append(response, version)
 	}
 
 	// Sort the commits by date
@@ -1,8 +1,12 @@
 package resource_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	resource "github.com/telia-oss/github-pr-resource"
 	"github.com/telia-oss/github-pr-resource/fakes"
@@ -20,8 +24,23 @@ var (
 		createTestPR(8, "master", false, false, 1, []string{"wontfix"}),
 		createTestPR(9, "master", false, false, 0, nil),
 	}
+	movedBasePullRequest = createMovedBasePR(testPullRequests[1], time.Now().Add(-1*time.Hour))
+	pushedPullRequest    = createPushedPR(testPullRequests[2], time.Now().Add(-45*time.Minute))
 )
 
+func createPushedPR(pr *resource.PullRequest, pushed time.Time) *resource.PullRequest {
+	p := *pr
+	p.Tip.CommittedDate = githubv4.DateTime{Time: pushed}
+	return &p
+}
+
+func createMovedBasePR(pr *resource.PullRequest, moved time.Time) *resource.PullRequest {
+	p := *pr
+	p.BaseTip.OID = fmt.Sprintf("moved%s", pr.BaseTip.OID)
+	p.BaseTip.CommittedDate = githubv4.DateTime{Time: moved}
+	return &p
+}
+
 func TestCheck(t *testing.T) {
 	tests := []struct {
 		description  string
@@ -185,6 +204,77 @@ func TestCheck(t *testing.T) {
 				resource.NewVersion(testPullRequests[6]),
 			},
 		},
+
+		{
+			description: "check ignores changes to the base branch by default",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version:      resource.NewVersion(testPullRequests[1]),
+			pullRequests: []*resource.PullRequest{movedBasePullRequest},
+			files:        [][]string{},
+			expected: resource.CheckResponse{
+				resource.NewVersion(testPullRequests[1]),
+			},
+		},
+
+		{
+			description: "check returns a new version when the base branch moves if enabled",
+			source: resource.Source{
+				Repository:          "itsdalmo/test-repository",
+				AccessToken:         "oauthtoken",
+				TriggerOnBaseChange: true,
+			},
+			version:      resource.NewVersion(testPullRequests[1]),
+			pullRequests: []*resource.PullRequest{movedBasePullRequest},
+			files:        [][]string{},
+			expected: resource.CheckResponse{
+				resource.Version{
+					PR:            "2",
+					Commit:        "oid2",
+					CommittedDate: movedBasePullRequest.BaseTip.CommittedDate.Time,
+					BaseCommit:    "movedbase2",
+				},
+			},
+		},
+
+		{
+			description: "check waits for the debounce window before returning a version for a moved base branch",
+			source: resource.Source{
+				Repository:          "itsdalmo/test-repository",
+				AccessToken:         "oauthtoken",
+				TriggerOnBaseChange: true,
+				BaseChangeDebounce:  "2h",
+			},
+			version:      resource.NewVersion(testPullRequests[1]),
+			pullRequests: []*resource.PullRequest{movedBasePullRequest},
+			files:        [][]string{},
+			expected: resource.CheckResponse{
+				resource.NewVersion(testPullRequests[1]),
+			},
+		},
+
+		{
+			description: "check returns a moved base branch after versions pushed during the debounce window",
+			source: resource.Source{
+				Repository:          "itsdalmo/test-repository",
+				AccessToken:         "oauthtoken",
+				TriggerOnBaseChange: true,
+				BaseChangeDebounce:  "30m",
+			},
+			version:      resource.NewVersion(pushedPullRequest),
+			pullRequests: []*resource.PullRequest{movedBasePullRequest, pushedPullRequest},
+			files:        [][]string{},
+			expected: resource.CheckResponse{
+				resource.Version{
+					PR:            "2",
+					Commit:        "oid2",
+					CommittedDate: movedBasePullRequest.BaseTip.CommittedDate.Time.Add(30 * time.Minute),
+					BaseCommit:    "movedbase2",
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -197,7 +287,7 @@ func TestCheck(t *testing.T) {
 			}
 
 			input := resource.CheckRequest{Source: tc.source, Version: tc.version}
-			output, err := resource.Check(input, github)
+			output, err := resource.Check(context.TODO(), input, github)
 
 			if assert.NoError(t, err) {
 				assert.Equal(t, tc.expected, output)
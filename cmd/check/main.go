@@ -21,11 +21,14 @@ func main() {
 	if err := request.Source.Validate(); err != nil {
 		log.Fatalf("invalid source configuration: %s", err)
 	}
+	ctx, cancel := request.Source.Context()
+	defer cancel()
+
 	github, err := resource.NewGithubClient(&request.Source)
 	if err != nil {
 		log.Fatalf("failed to create github manager: %s", err)
 	}
-	response, err := resource.Check(request, github)
+	response, err := resource.Check(ctx, request, github)
 	if err != nil {
 		log.Fatalf("check failed: %s", err)
 	}
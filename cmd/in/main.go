@@ -25,15 +25,23 @@ func main() {
 	if err := request.Source.Validate(); err != nil {
 		log.Fatalf("invalid source configuration: %s", err)
 	}
+	ctx, cancel := request.Source.Context()
+	defer cancel()
+
+	github, err := resource.NewGithubClient(&request.Source)
+	if err != nil {
+		log.Fatalf("failed to create github manager: %s", err)
+	}
 	git, err := resource.NewGitClient(&request.Source, outputDir, os.Stderr)
 	if err != nil {
 		log.Fatalf("failed to create git client: %s", err)
 	}
-	github, err := resource.NewGithubClient(&request.Source)
-	if err != nil {
-		log.Fatalf("failed to create github manager: %s", err)
+	response, err := resource.Get(ctx, request, github, git, outputDir)
+
+	// Remove the private key before exiting (log.Fatalf does not run deferred functions).
+	if err := git.Close(); err != nil {
+		log.Printf("failed to remove private key: %s", err)
 	}
-	response, err := resource.Get(request, github, git, outputDir)
 	if err != nil {
 		log.Fatalf("get failed: %s", err)
 	}
@@ -25,11 +25,14 @@ func main() {
 	if err := request.Source.Validate(); err != nil {
 		log.Fatalf("invalid source configuration: %s", err)
 	}
+	ctx, cancel := request.Source.Context()
+	defer cancel()
+
 	github, err := resource.NewGithubClient(&request.Source)
 	if err != nil {
 		log.Fatalf("failed to create github manager: %s", err)
 	}
-	response, err := resource.Put(request, github, sourceDir)
+	response, err := resource.Put(ctx, request, github, sourceDir)
 	if err != nil {
 		log.Fatalf("put failed: %s", err)
 	}
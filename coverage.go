@@ -0,0 +1,355 @@
+package resource
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// coverageProfile maps files to the hit count of each instrumented line.
+type coverageProfile map[string]map[int]int
+
+func (c coverageProfile) add(file string, line, hits int) {
+	if c[file] == nil {
+		c[file] = make(map[int]int)
+	}
+	c[file][line] += hits
+}
+
+// count returns the lines covered and instrumented in a file. All files are used if lines is nil,
+// otherwise only the listed lines are counted.
+func (c coverageProfile) count(file string, lines map[int]bool) (covered, total int) {
+	for line, hits := range c[file] {
+		if lines != nil && !lines[line] {
+			continue
+		}
+		total++
+		if hits > 0 {
+			covered++
+		}
+	}
+	return covered, total
+}
+
+// lookup finds the coverage entry for a path in the repository. Coverage files rarely use paths relative
+// to the repository root (e.g. Go uses import paths and LCOV absolute paths), so we match on suffix.
+// When several entries match, the shortest one is used (and the first in lexical order on ties), since
+// e.g. "pkg/main.go" should not match "github.com/o/r/cmd/pkg/main.go" over "github.com/o/r/pkg/main.go".
+func (c coverageProfile) lookup(path string) (string, bool) {
+	if _, ok := c[path]; ok {
+		return path, true
+	}
+	var match string
+	for file := range c {
+		if !strings.HasSuffix(file, "/"+path) {
+			continue
+		}
+		if match == "" || len(file) < len(match) || (len(file) == len(match) && file < match) {
+			match = file
+		}
+	}
+	return match, match != ""
+}
+
+// readCoverageFiles parses all coverage files matching the patterns (relative to dir). Go cover profiles,
+// Cobertura XML and LCOV are supported, and the format is detected from the contents of each file.
+func readCoverageFiles(dir string, patterns []string) (coverageProfile, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid coverage pattern %s: %s", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no coverage files found matching: %s", strings.Join(patterns, ", "))
+	}
+	sort.Strings(files)
+
+	profile := make(coverageProfile)
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read coverage file: %s", err)
+		}
+		text := strings.TrimSpace(string(content))
+
+		switch {
+		case strings.HasPrefix(text, "mode:"):
+			err = parseGoCoverage(text, profile)
+		case strings.HasPrefix(text, "<"):
+			err = parseCoberturaCoverage(content, profile)
+		default:
+			err = parseLCOVCoverage(text, profile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse coverage file %s: %s", f, err)
+		}
+	}
+	return profile, nil
+}
+
+// parseGoCoverage parses a profile written by go test -coverprofile, where each line is a block:
+// name.go:line.column,line.column numberOfStatements count
+func parseGoCoverage(text string, profile coverageProfile) error {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		var file string
+		var startLine, startCol, endLine, endCol, statements, count int
+		i := strings.LastIndex(line, ":")
+		if i < 0 {
+			return fmt.Errorf("invalid line: %s", line)
+		}
+		file = line[:i]
+		if _, err := fmt.Sscanf(line[i+1:], "%d.%d,%d.%d %d %d", &startLine, &startCol, &endLine, &endCol, &statements, &count); err != nil {
+			return fmt.Errorf("invalid line: %s", line)
+		}
+		for l := startLine; l <= endLine; l++ {
+			profile.add(file, l, count)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseCoberturaCoverage parses a Cobertura XML report.
+func parseCoberturaCoverage(content []byte, profile coverageProfile) error {
+	var report struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number int `xml:"number,attr"`
+				Hits   int `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"packages>package>classes>class"`
+	}
+	if err := xml.Unmarshal(content, &report); err != nil {
+		return err
+	}
+	for _, c := range report.Classes {
+		for _, l := range c.Lines {
+			profile.add(c.Filename, l.Number, l.Hits)
+		}
+	}
+	return nil
+}
+
+// parseLCOVCoverage parses the line records (SF and DA) of an LCOV tracefile.
+func parseLCOVCoverage(text string, profile coverageProfile) error {
+	var file string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file = strings.TrimPrefix(line, "SF:")
+		case strings.HasPrefix(line, "DA:"):
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if file == "" || len(fields) < 2 {
+				return fmt.Errorf("invalid line: %s", line)
+			}
+			number, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return fmt.Errorf("invalid line: %s", line)
+			}
+			hits, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return fmt.Errorf("invalid line: %s", line)
+			}
+			profile.add(file, number, hits)
+		case line == "end_of_record":
+			file = ""
+		}
+	}
+	return scanner.Err()
+}
+
+// addedLines returns the lines added (or modified) in each file of a unified diff. The lines of each hunk are
+// counted using its header, so that added lines which look like file headers (e.g. "+++ ") are not mistaken for one.
+func addedLines(diff string) (map[string]map[int]bool, error) {
+	files := make(map[string]map[int]bool)
+
+	var file string
+	// The current line in the new file, and the lines remaining of the current hunk in the old and new file.
+	var line, oldLines, newLines int
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		if oldLines > 0 || newLines > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if file != "" {
+					if files[file] == nil {
+						files[file] = make(map[int]bool)
+					}
+					files[file][line] = true
+				}
+				line++
+				newLines--
+			case strings.HasPrefix(text, "-"):
+				oldLines--
+			case strings.HasPrefix(text, "\\"):
+				// \ No newline at end of file
+			default:
+				line++
+				oldLines--
+				newLines--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(text, "+++ "), "b/")
+			if file == "/dev/null" {
+				file = ""
+			}
+		case strings.HasPrefix(text, "@@ "):
+			// @@ -start[,count] +start[,count] @@
+			fields := strings.Fields(text)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid hunk header: %s", text)
+			}
+			var err error
+			if _, oldLines, err = parseHunkRange(fields[1], "-"); err != nil {
+				return nil, fmt.Errorf("invalid hunk header: %s", text)
+			}
+			if line, newLines, err = parseHunkRange(fields[2], "+"); err != nil {
+				return nil, fmt.Errorf("invalid hunk header: %s", text)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read diff: %s", err)
+	}
+	return files, nil
+}
+
+// parseHunkRange parses the start and number of lines of a range in a hunk header (e.g. +1,5). The number of lines is 1 if omitted.
+func parseHunkRange(s, prefix string) (int, int, error) {
+	if !strings.HasPrefix(s, prefix) {
+		return 0, 0, fmt.Errorf("invalid range: %s", s)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(s, prefix), ",", 2)
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	count := 1
+	if len(parts) == 2 {
+		if count, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+	return start, count, nil
+}
+
+// coverageReport is the total and patch coverage of a pull request.
+type coverageReport struct {
+	Covered      int
+	Total        int
+	PatchCovered int
+	PatchTotal   int
+	Files        []fileCoverage
+}
+
+type fileCoverage struct {
+	Path         string
+	Covered      int
+	Total        int
+	PatchCovered int
+	PatchTotal   int
+}
+
+// newCoverageReport computes the total coverage of the profile, and the coverage of the lines added in the diff.
+func newCoverageReport(profile coverageProfile, diff string) (*coverageReport, error) {
+	report := &coverageReport{}
+	for file := range profile {
+		covered, total := profile.count(file, nil)
+		report.Covered += covered
+		report.Total += total
+	}
+
+	changed, err := addedLines(diff)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(changed))
+	for path := range changed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		file, ok := profile.lookup(path)
+		if !ok {
+			continue
+		}
+		f := fileCoverage{Path: path}
+		f.Covered, f.Total = profile.count(file, nil)
+		f.PatchCovered, f.PatchTotal = profile.count(file, changed[path])
+		report.PatchCovered += f.PatchCovered
+		report.PatchTotal += f.PatchTotal
+		report.Files = append(report.Files, f)
+	}
+	return report, nil
+}
+
+// Status returns the commit status and description for the report. The status is a failure when the patch
+// coverage is below the threshold (in percent).
+func (r *coverageReport) Status(threshold float64) (string, string) {
+	status := "success"
+	if r.PatchTotal > 0 && percent(r.PatchCovered, r.PatchTotal) < threshold {
+		status = "failure"
+	}
+	description := fmt.Sprintf("Total %s, patch %s", formatPercent(r.Covered, r.Total), formatPercent(r.PatchCovered, r.PatchTotal))
+	if threshold > 0 {
+		description += fmt.Sprintf(" (threshold %.2f%%)", threshold)
+	}
+	return status, description
+}
+
+// Markdown renders the coverage of each changed file as a comment.
+func (r *coverageReport) Markdown() string {
+	var b strings.Builder
+	b.WriteString("### Coverage\n\n")
+	b.WriteString("| File | Coverage | Patch coverage |\n")
+	b.WriteString("|------|----------|----------------|\n")
+	for _, f := range r.Files {
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", escapeTableCell(f.Path), formatCoverage(f.Covered, f.Total), formatCoverage(f.PatchCovered, f.PatchTotal))
+	}
+	fmt.Fprintf(&b, "| **Total** | %s | %s |\n", formatCoverage(r.Covered, r.Total), formatCoverage(r.PatchCovered, r.PatchTotal))
+	return b.String()
+}
+
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) / float64(total) * 100
+}
+
+func formatPercent(covered, total int) string {
+	if total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f%%", percent(covered, total))
+}
+
+func formatCoverage(covered, total int) string {
+	if total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%s (%d/%d)", formatPercent(covered, total), covered, total)
+}
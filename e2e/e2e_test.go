@@ -32,6 +32,7 @@ var (
 	developCommitID      = "ac771f3b69cbd63b22bbda553f827ab36150c640"
 	developPullRequestID = "6"
 	developDateTime      = time.Date(2018, time.September, 25, 21, 00, 16, 0, time.UTC)
+	baseCommitID         = "93eeeedb8a16e6662062d1eca5655108977cc59a"
 )
 
 func TestCheckE2E(t *testing.T) {
@@ -49,7 +50,7 @@ func TestCheckE2E(t *testing.T) {
 			},
 			version: resource.Version{},
 			expected: resource.CheckResponse{
-				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime},
+				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -59,9 +60,9 @@ func TestCheckE2E(t *testing.T) {
 				Repository:  "itsdalmo/test-repository",
 				AccessToken: os.Getenv("GITHUB_ACCESS_TOKEN"),
 			},
-			version: resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime},
+			version: resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime, BaseCommit: baseCommitID},
 			expected: resource.CheckResponse{
-				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime},
+				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -71,9 +72,9 @@ func TestCheckE2E(t *testing.T) {
 				Repository:  "itsdalmo/test-repository",
 				AccessToken: os.Getenv("GITHUB_ACCESS_TOKEN"),
 			},
-			version: resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime},
+			version: resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime, BaseCommit: baseCommitID},
 			expected: resource.CheckResponse{
-				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime},
+				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -86,7 +87,7 @@ func TestCheckE2E(t *testing.T) {
 			},
 			version: resource.Version{},
 			expected: resource.CheckResponse{
-				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime},
+				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -99,7 +100,7 @@ func TestCheckE2E(t *testing.T) {
 			},
 			version: resource.Version{},
 			expected: resource.CheckResponse{
-				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime},
+				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -113,7 +114,20 @@ func TestCheckE2E(t *testing.T) {
 			},
 			version: resource.Version{},
 			expected: resource.CheckResponse{
-				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime},
+				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime, BaseCommit: baseCommitID},
+			},
+		},
+
+		{
+			description: "check works with a timeout",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: os.Getenv("GITHUB_ACCESS_TOKEN"),
+				Timeout:     "1m",
+			},
+			version: resource.Version{},
+			expected: resource.CheckResponse{
+				resource.Version{PR: latestPullRequestID, Commit: latestCommitID, CommittedDate: latestDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -129,7 +143,7 @@ func TestCheckE2E(t *testing.T) {
 			},
 			version: resource.Version{},
 			expected: resource.CheckResponse{
-				resource.Version{PR: developPullRequestID, Commit: developCommitID, CommittedDate: developDateTime},
+				resource.Version{PR: developPullRequestID, Commit: developCommitID, CommittedDate: developDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -144,7 +158,7 @@ func TestCheckE2E(t *testing.T) {
 			},
 			version: resource.Version{},
 			expected: resource.CheckResponse{
-				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime},
+				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime, BaseCommit: baseCommitID},
 			},
 		},
 
@@ -170,7 +184,7 @@ func TestCheckE2E(t *testing.T) {
 			},
 			version: resource.Version{},
 			expected: resource.CheckResponse{
-				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime},
+				resource.Version{PR: targetPullRequestID, Commit: targetCommitID, CommittedDate: targetDateTime, BaseCommit: baseCommitID},
 			},
 		},
 	}
@@ -180,8 +194,11 @@ func TestCheckE2E(t *testing.T) {
 			githubClient, err := resource.NewGithubClient(&tc.source)
 			require.NoError(t, err)
 
+			ctx, cancel := tc.source.Context()
+			defer cancel()
+
 			input := resource.CheckRequest{Source: tc.source, Version: tc.version}
-			output, err := resource.Check(input, githubClient)
+			output, err := resource.Check(ctx, input, githubClient)
 
 			if assert.NoError(t, err) {
 				assert.Equal(t, tc.expected, output)
@@ -216,7 +233,7 @@ func TestCheckAPICostE2E(t *testing.T) {
 			before := getRemainingRateLimit(t, githubClient.V4)
 
 			input := resource.CheckRequest{Source: tc.source, Version: tc.version}
-			_, err = resource.Check(input, githubClient)
+			_, err = resource.Check(context.TODO(), input, githubClient)
 			require.NoError(t, err)
 
 			cost := before - getRemainingRateLimit(t, githubClient.V4)
@@ -255,7 +272,7 @@ func TestGetAndPutE2E(t *testing.T) {
 			getParameters:  resource.GetParameters{},
 			putParameters:  resource.PutParameters{},
 			versionString:  `{"pr":"4","commit":"a5114f6ab89f4b736655642a11e8d15ce363d882","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString: `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"}]`,
+			metadataString: `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":"enhancement"},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
 			metadataFiles: map[string]string{
 				"pr":        "4",
 				"url":       "https://github.com/itsdalmo/test-repository/pull/4",
@@ -287,10 +304,32 @@ func TestGetAndPutE2E(t *testing.T) {
 			},
 			putParameters:       resource.PutParameters{},
 			versionString:       `{"pr":"4","commit":"a5114f6ab89f4b736655642a11e8d15ce363d882","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"}]`,
+			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":"enhancement"},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
 			expectedCommitCount: 9,
 			expectedCommits:     []string{"Push 2."},
 		},
+		{
+			description: "get works when squashing",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				V3Endpoint:  "https://api.github.com/",
+				V4Endpoint:  "https://api.github.com/graphql",
+				AccessToken: os.Getenv("GITHUB_ACCESS_TOKEN"),
+			},
+			version: resource.Version{
+				PR:            targetPullRequestID,
+				Commit:        targetCommitID,
+				CommittedDate: time.Time{},
+			},
+			getParameters: resource.GetParameters{
+				IntegrationTool: "squash",
+			},
+			putParameters:       resource.PutParameters{},
+			versionString:       `{"pr":"4","commit":"a5114f6ab89f4b736655642a11e8d15ce363d882","committed":"0001-01-01T00:00:00Z"}`,
+			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":"enhancement"},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
+			expectedCommitCount: 5,
+			expectedCommits:     []string{"Add comment from 2nd pull request. (#4)"},
+		},
 		{
 			description: "get works when checkout",
 			source: resource.Source{
@@ -309,7 +348,7 @@ func TestGetAndPutE2E(t *testing.T) {
 			},
 			putParameters:       resource.PutParameters{},
 			versionString:       `{"pr":"4","commit":"a5114f6ab89f4b736655642a11e8d15ce363d882","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"}]`,
+			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":"enhancement"},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
 			expectedCommitCount: 7,
 			expectedCommits: []string{
 				"Push 2.",
@@ -337,7 +376,7 @@ func TestGetAndPutE2E(t *testing.T) {
 			getParameters:       resource.GetParameters{},
 			putParameters:       resource.PutParameters{},
 			versionString:       `{"pr":"6","commit":"ac771f3b69cbd63b22bbda553f827ab36150c640","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString:      `[{"name":"pr","value":"6"},{"name":"title","value":"[skip ci] Add a PR with a non-master base"},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/6"},{"name":"head_name","value":"test-develop-pr"},{"name":"head_sha","value":"ac771f3b69cbd63b22bbda553f827ab36150c640"},{"name":"base_name","value":"develop"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"[skip ci] Add a PR with a non-master base"},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"}]`,
+			metadataString:      `[{"name":"pr","value":"6"},{"name":"title","value":"[skip ci] Add a PR with a non-master base"},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/6"},{"name":"head_name","value":"test-develop-pr"},{"name":"head_sha","value":"ac771f3b69cbd63b22bbda553f827ab36150c640"},{"name":"base_name","value":"develop"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"[skip ci] Add a PR with a non-master base"},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
 			expectedCommitCount: 5,
 			expectedCommits:     []string{"[skip ci] Add a PR with a non-master base"}, // This merge ends up being fast-forwarded
 		},
@@ -358,7 +397,7 @@ func TestGetAndPutE2E(t *testing.T) {
 			getParameters:       resource.GetParameters{},
 			putParameters:       resource.PutParameters{},
 			versionString:       `{"pr":"4","commit":"a5114f6ab89f4b736655642a11e8d15ce363d882","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"}]`,
+			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":"enhancement"},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
 			expectedCommitCount: 10,
 			expectedCommits:     []string{"Merge commit 'a5114f6ab89f4b736655642a11e8d15ce363d882'"},
 		},
@@ -376,7 +415,7 @@ func TestGetAndPutE2E(t *testing.T) {
 			getParameters:       resource.GetParameters{GitDepth: 6},
 			putParameters:       resource.PutParameters{},
 			versionString:       `{"pr":"4","commit":"a5114f6ab89f4b736655642a11e8d15ce363d882","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"}]`,
+			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":"enhancement"},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
 			expectedCommitCount: 9,
 			expectedCommits: []string{
 				"Merge commit 'a5114f6ab89f4b736655642a11e8d15ce363d882'",
@@ -406,7 +445,7 @@ func TestGetAndPutE2E(t *testing.T) {
 			},
 			putParameters:       resource.PutParameters{},
 			versionString:       `{"pr":"4","commit":"a5114f6ab89f4b736655642a11e8d15ce363d882","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"}]`,
+			metadataString:      `[{"name":"pr","value":"4"},{"name":"title","value":"Add comment from 2nd pull request."},{"name":"url","value":"https://github.com/itsdalmo/test-repository/pull/4"},{"name":"head_name","value":"my_second_pull"},{"name":"head_sha","value":"a5114f6ab89f4b736655642a11e8d15ce363d882"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"93eeeedb8a16e6662062d1eca5655108977cc59a"},{"name":"message","value":"Push 2."},{"name":"author","value":"itsdalmo"},{"name":"author_email","value":"kristian@doingit.no"},{"name":"labels","value":"enhancement"},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"$CREATED_AT"},{"name":"updated_at","value":"$UPDATED_AT"}]`,
 			filesString:         "README.md\ntest.txt\n",
 			expectedCommitCount: 10,
 			expectedCommits:     []string{"Merge commit 'a5114f6ab89f4b736655642a11e8d15ce363d882'"},
@@ -425,10 +464,11 @@ func TestGetAndPutE2E(t *testing.T) {
 
 			git, err := resource.NewGitClient(&tc.source, dir, ioutil.Discard)
 			require.NoError(t, err)
+			defer git.Close()
 
 			// Get (output and files)
 			getRequest := resource.GetRequest{Source: tc.source, Version: tc.version, Params: tc.getParameters}
-			getOutput, err := resource.Get(getRequest, githubClient, git, dir)
+			getOutput, err := resource.Get(context.TODO(), getRequest, githubClient, git, dir)
 
 			require.NoError(t, err)
 			assert.Equal(t, tc.version, getOutput.Version)
@@ -436,8 +476,16 @@ func TestGetAndPutE2E(t *testing.T) {
 			version := readTestFile(t, filepath.Join(dir, ".git", "resource", "version.json"))
 			assert.Equal(t, tc.versionString, version)
 
+			// The timestamps of the pull request change whenever it is commented on (e.g. by put).
+			pull, err := githubClient.GetPullRequest(context.TODO(), tc.version.PR, tc.version.Commit)
+			require.NoError(t, err)
+			expectedMetadata := strings.NewReplacer(
+				"$CREATED_AT", pull.CreatedAt.Format(time.RFC3339),
+				"$UPDATED_AT", pull.UpdatedAt.Format(time.RFC3339),
+			).Replace(tc.metadataString)
+
 			metadata := readTestFile(t, filepath.Join(dir, ".git", "resource", "metadata.json"))
-			assert.Equal(t, tc.metadataString, metadata)
+			assert.Equal(t, expectedMetadata, metadata)
 
 			if tc.getParameters.ListChangedFiles {
 				changedFiles := readTestFile(t, filepath.Join(dir, ".git", "resource", "changed_files"))
@@ -463,7 +511,7 @@ func TestGetAndPutE2E(t *testing.T) {
 
 			// Put
 			putRequest := resource.PutRequest{Source: tc.source, Params: tc.putParameters}
-			putOutput, err := resource.Put(putRequest, githubClient, dir)
+			putOutput, err := resource.Put(context.TODO(), putRequest, githubClient, dir)
 
 			require.NoError(t, err)
 			assert.Equal(t, tc.version, putOutput.Version)
@@ -530,10 +578,11 @@ func TestGetSubmodules(t *testing.T) {
 
 			git, err := resource.NewGitClient(&tc.source, dir, ioutil.Discard)
 			require.NoError(t, err)
+			defer git.Close()
 
 			// Get (output and files)
 			getRequest := resource.GetRequest{Source: tc.source, Version: tc.version, Params: tc.getParameters}
-			_, err = resource.Get(getRequest, githubClient, git, dir)
+			_, err = resource.Get(context.TODO(), getRequest, githubClient, git, dir)
 			require.NoError(t, err)
 
 			files, err := ioutil.ReadDir(filepath.Join(dir, "submodule"))
@@ -624,6 +673,7 @@ func TestPutCommentsE2E(t *testing.T) {
 
 			git, err := resource.NewGitClient(&tc.source, dir, ioutil.Discard)
 			require.NoError(t, err)
+			defer git.Close()
 
 			pullRequest, _, err := githubClient.V3.PullRequests.Create(context.TODO(), owner, repository, &github.NewPullRequest{
 				Title: github.String(tc.description),
@@ -643,7 +693,7 @@ func TestPutCommentsE2E(t *testing.T) {
 				PR:     strconv.Itoa(pullRequest.GetNumber()),
 				Commit: pullRequest.GetHead().GetSHA(),
 			}, Params: tc.getParams}
-			_, err = resource.Get(getRequest, githubClient, git, dir)
+			_, err = resource.Get(context.TODO(), getRequest, githubClient, git, dir)
 			require.NoError(t, err)
 
 			putRequest := resource.PutRequest{
@@ -651,7 +701,7 @@ func TestPutCommentsE2E(t *testing.T) {
 				Params: tc.putParameters,
 			}
 
-			_, err = resource.Put(putRequest, githubClient, dir)
+			_, err = resource.Put(context.TODO(), putRequest, githubClient, dir)
 			require.NoError(t, err)
 
 			comments, _, err := githubClient.V3.Issues.ListComments(context.TODO(), owner, repository, pullRequest.GetNumber(), nil)
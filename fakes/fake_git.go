@@ -2,18 +2,20 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	resource "github.com/telia-oss/github-pr-resource"
 )
 
 type FakeGit struct {
-	CheckoutStub        func(string, string, bool) error
+	CheckoutStub        func(context.Context, string, string, bool) error
 	checkoutMutex       sync.RWMutex
 	checkoutArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 bool
+		arg3 string
+		arg4 bool
 	}
 	checkoutReturns struct {
 		result1 error
@@ -21,13 +23,28 @@ type FakeGit struct {
 	checkoutReturnsOnCall map[int]struct {
 		result1 error
 	}
-	FetchStub        func(string, int, int, bool) error
+	ConflictsStub        func(context.Context) ([]string, error)
+	conflictsMutex       sync.RWMutex
+	conflictsArgsForCall []struct {
+		arg1 context.Context
+	}
+	conflictsReturns struct {
+		result1 []string
+		result2 error
+	}
+	conflictsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FetchStub        func(context.Context, string, int, int, bool, bool) error
 	fetchMutex       sync.RWMutex
 	fetchArgsForCall []struct {
-		arg1 string
-		arg2 int
+		arg1 context.Context
+		arg2 string
 		arg3 int
-		arg4 bool
+		arg4 int
+		arg5 bool
+		arg6 bool
 	}
 	fetchReturns struct {
 		result1 error
@@ -35,10 +52,43 @@ type FakeGit struct {
 	fetchReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GitCryptUnlockStub        func(string) error
+	FetchCommitStub        func(context.Context, string, string, int, bool, bool) error
+	fetchCommitMutex       sync.RWMutex
+	fetchCommitArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 bool
+		arg6 bool
+	}
+	fetchCommitReturns struct {
+		result1 error
+	}
+	fetchCommitReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FetchMergeStub        func(context.Context, string, int, int, bool, bool) error
+	fetchMergeMutex       sync.RWMutex
+	fetchMergeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 bool
+		arg6 bool
+	}
+	fetchMergeReturns struct {
+		result1 error
+	}
+	fetchMergeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GitCryptUnlockStub        func(context.Context, string) error
 	gitCryptUnlockMutex       sync.RWMutex
 	gitCryptUnlockArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	gitCryptUnlockReturns struct {
 		result1 error
@@ -46,10 +96,11 @@ type FakeGit struct {
 	gitCryptUnlockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InitStub        func(string) error
+	InitStub        func(context.Context, string) error
 	initMutex       sync.RWMutex
 	initArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	initReturns struct {
 		result1 error
@@ -57,11 +108,26 @@ type FakeGit struct {
 	initReturnsOnCall map[int]struct {
 		result1 error
 	}
-	MergeStub        func(string, bool) error
+	LFSPullStub        func(context.Context, string, []string, []string) error
+	lFSPullMutex       sync.RWMutex
+	lFSPullArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+		arg4 []string
+	}
+	lFSPullReturns struct {
+		result1 error
+	}
+	lFSPullReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MergeStub        func(context.Context, string, bool) error
 	mergeMutex       sync.RWMutex
 	mergeArgsForCall []struct {
-		arg1 string
-		arg2 bool
+		arg1 context.Context
+		arg2 string
+		arg3 bool
 	}
 	mergeReturns struct {
 		result1 error
@@ -69,13 +135,29 @@ type FakeGit struct {
 	mergeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PullStub        func(string, string, int, bool) error
+	ParentsStub        func(context.Context, string) ([]string, error)
+	parentsMutex       sync.RWMutex
+	parentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	parentsReturns struct {
+		result1 []string
+		result2 error
+	}
+	parentsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	PullStub        func(context.Context, string, string, int, bool, bool) error
 	pullMutex       sync.RWMutex
 	pullArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 int
-		arg4 bool
+		arg3 string
+		arg4 int
+		arg5 bool
+		arg6 bool
 	}
 	pullReturns struct {
 		result1 error
@@ -83,12 +165,13 @@ type FakeGit struct {
 	pullReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RebaseStub        func(string, string, bool) error
+	RebaseStub        func(context.Context, string, string, bool) error
 	rebaseMutex       sync.RWMutex
 	rebaseArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 bool
+		arg3 string
+		arg4 bool
 	}
 	rebaseReturns struct {
 		result1 error
@@ -96,10 +179,24 @@ type FakeGit struct {
 	rebaseReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RevParseStub        func(string) (string, error)
+	ResetStub        func(context.Context, string, bool) error
+	resetMutex       sync.RWMutex
+	resetArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}
+	resetReturns struct {
+		result1 error
+	}
+	resetReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevParseStub        func(context.Context, string) (string, error)
 	revParseMutex       sync.RWMutex
 	revParseArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	revParseReturns struct {
 		result1 string
@@ -109,22 +206,62 @@ type FakeGit struct {
 		result1 string
 		result2 error
 	}
+	SparseCheckoutStub        func(context.Context, []string) error
+	sparseCheckoutMutex       sync.RWMutex
+	sparseCheckoutArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	sparseCheckoutReturns struct {
+		result1 error
+	}
+	sparseCheckoutReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SquashStub        func(context.Context, string, string, bool) error
+	squashMutex       sync.RWMutex
+	squashArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 bool
+	}
+	squashReturns struct {
+		result1 error
+	}
+	squashReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VerifyCommitStub        func(context.Context, string, string) error
+	verifyCommitMutex       sync.RWMutex
+	verifyCommitArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	verifyCommitReturns struct {
+		result1 error
+	}
+	verifyCommitReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeGit) Checkout(arg1 string, arg2 string, arg3 bool) error {
+func (fake *FakeGit) Checkout(arg1 context.Context, arg2 string, arg3 string, arg4 bool) error {
 	fake.checkoutMutex.Lock()
 	ret, specificReturn := fake.checkoutReturnsOnCall[len(fake.checkoutArgsForCall)]
 	fake.checkoutArgsForCall = append(fake.checkoutArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 bool
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("Checkout", []interface{}{arg1, arg2, arg3})
+		arg3 string
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Checkout", []interface{}{arg1, arg2, arg3, arg4})
 	fake.checkoutMutex.Unlock()
 	if fake.CheckoutStub != nil {
-		return fake.CheckoutStub(arg1, arg2, arg3)
+		return fake.CheckoutStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -139,17 +276,17 @@ func (fake *FakeGit) CheckoutCallCount() int {
 	return len(fake.checkoutArgsForCall)
 }
 
-func (fake *FakeGit) CheckoutCalls(stub func(string, string, bool) error) {
+func (fake *FakeGit) CheckoutCalls(stub func(context.Context, string, string, bool) error) {
 	fake.checkoutMutex.Lock()
 	defer fake.checkoutMutex.Unlock()
 	fake.CheckoutStub = stub
 }
 
-func (fake *FakeGit) CheckoutArgsForCall(i int) (string, string, bool) {
+func (fake *FakeGit) CheckoutArgsForCall(i int) (context.Context, string, string, bool) {
 	fake.checkoutMutex.RLock()
 	defer fake.checkoutMutex.RUnlock()
 	argsForCall := fake.checkoutArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeGit) CheckoutReturns(result1 error) {
@@ -175,19 +312,84 @@ func (fake *FakeGit) CheckoutReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGit) Fetch(arg1 string, arg2 int, arg3 int, arg4 bool) error {
+func (fake *FakeGit) Conflicts(arg1 context.Context) ([]string, error) {
+	fake.conflictsMutex.Lock()
+	ret, specificReturn := fake.conflictsReturnsOnCall[len(fake.conflictsArgsForCall)]
+	fake.conflictsArgsForCall = append(fake.conflictsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("Conflicts", []interface{}{arg1})
+	fake.conflictsMutex.Unlock()
+	if fake.ConflictsStub != nil {
+		return fake.ConflictsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.conflictsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGit) ConflictsCallCount() int {
+	fake.conflictsMutex.RLock()
+	defer fake.conflictsMutex.RUnlock()
+	return len(fake.conflictsArgsForCall)
+}
+
+func (fake *FakeGit) ConflictsCalls(stub func(context.Context) ([]string, error)) {
+	fake.conflictsMutex.Lock()
+	defer fake.conflictsMutex.Unlock()
+	fake.ConflictsStub = stub
+}
+
+func (fake *FakeGit) ConflictsArgsForCall(i int) context.Context {
+	fake.conflictsMutex.RLock()
+	defer fake.conflictsMutex.RUnlock()
+	argsForCall := fake.conflictsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGit) ConflictsReturns(result1 []string, result2 error) {
+	fake.conflictsMutex.Lock()
+	defer fake.conflictsMutex.Unlock()
+	fake.ConflictsStub = nil
+	fake.conflictsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGit) ConflictsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.conflictsMutex.Lock()
+	defer fake.conflictsMutex.Unlock()
+	fake.ConflictsStub = nil
+	if fake.conflictsReturnsOnCall == nil {
+		fake.conflictsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.conflictsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGit) Fetch(arg1 context.Context, arg2 string, arg3 int, arg4 int, arg5 bool, arg6 bool) error {
 	fake.fetchMutex.Lock()
 	ret, specificReturn := fake.fetchReturnsOnCall[len(fake.fetchArgsForCall)]
 	fake.fetchArgsForCall = append(fake.fetchArgsForCall, struct {
-		arg1 string
-		arg2 int
+		arg1 context.Context
+		arg2 string
 		arg3 int
-		arg4 bool
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("Fetch", []interface{}{arg1, arg2, arg3, arg4})
+		arg4 int
+		arg5 bool
+		arg6 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("Fetch", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.fetchMutex.Unlock()
 	if fake.FetchStub != nil {
-		return fake.FetchStub(arg1, arg2, arg3, arg4)
+		return fake.FetchStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -202,17 +404,17 @@ func (fake *FakeGit) FetchCallCount() int {
 	return len(fake.fetchArgsForCall)
 }
 
-func (fake *FakeGit) FetchCalls(stub func(string, int, int, bool) error) {
+func (fake *FakeGit) FetchCalls(stub func(context.Context, string, int, int, bool, bool) error) {
 	fake.fetchMutex.Lock()
 	defer fake.fetchMutex.Unlock()
 	fake.FetchStub = stub
 }
 
-func (fake *FakeGit) FetchArgsForCall(i int) (string, int, int, bool) {
+func (fake *FakeGit) FetchArgsForCall(i int) (context.Context, string, int, int, bool, bool) {
 	fake.fetchMutex.RLock()
 	defer fake.fetchMutex.RUnlock()
 	argsForCall := fake.fetchArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeGit) FetchReturns(result1 error) {
@@ -238,16 +440,147 @@ func (fake *FakeGit) FetchReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGit) GitCryptUnlock(arg1 string) error {
+func (fake *FakeGit) FetchCommit(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 bool, arg6 bool) error {
+	fake.fetchCommitMutex.Lock()
+	ret, specificReturn := fake.fetchCommitReturnsOnCall[len(fake.fetchCommitArgsForCall)]
+	fake.fetchCommitArgsForCall = append(fake.fetchCommitArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 bool
+		arg6 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("FetchCommit", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.fetchCommitMutex.Unlock()
+	if fake.FetchCommitStub != nil {
+		return fake.FetchCommitStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.fetchCommitReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGit) FetchCommitCallCount() int {
+	fake.fetchCommitMutex.RLock()
+	defer fake.fetchCommitMutex.RUnlock()
+	return len(fake.fetchCommitArgsForCall)
+}
+
+func (fake *FakeGit) FetchCommitCalls(stub func(context.Context, string, string, int, bool, bool) error) {
+	fake.fetchCommitMutex.Lock()
+	defer fake.fetchCommitMutex.Unlock()
+	fake.FetchCommitStub = stub
+}
+
+func (fake *FakeGit) FetchCommitArgsForCall(i int) (context.Context, string, string, int, bool, bool) {
+	fake.fetchCommitMutex.RLock()
+	defer fake.fetchCommitMutex.RUnlock()
+	argsForCall := fake.fetchCommitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeGit) FetchCommitReturns(result1 error) {
+	fake.fetchCommitMutex.Lock()
+	defer fake.fetchCommitMutex.Unlock()
+	fake.FetchCommitStub = nil
+	fake.fetchCommitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) FetchCommitReturnsOnCall(i int, result1 error) {
+	fake.fetchCommitMutex.Lock()
+	defer fake.fetchCommitMutex.Unlock()
+	fake.FetchCommitStub = nil
+	if fake.fetchCommitReturnsOnCall == nil {
+		fake.fetchCommitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.fetchCommitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) FetchMerge(arg1 context.Context, arg2 string, arg3 int, arg4 int, arg5 bool, arg6 bool) error {
+	fake.fetchMergeMutex.Lock()
+	ret, specificReturn := fake.fetchMergeReturnsOnCall[len(fake.fetchMergeArgsForCall)]
+	fake.fetchMergeArgsForCall = append(fake.fetchMergeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 bool
+		arg6 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("FetchMerge", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.fetchMergeMutex.Unlock()
+	if fake.FetchMergeStub != nil {
+		return fake.FetchMergeStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.fetchMergeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGit) FetchMergeCallCount() int {
+	fake.fetchMergeMutex.RLock()
+	defer fake.fetchMergeMutex.RUnlock()
+	return len(fake.fetchMergeArgsForCall)
+}
+
+func (fake *FakeGit) FetchMergeCalls(stub func(context.Context, string, int, int, bool, bool) error) {
+	fake.fetchMergeMutex.Lock()
+	defer fake.fetchMergeMutex.Unlock()
+	fake.FetchMergeStub = stub
+}
+
+func (fake *FakeGit) FetchMergeArgsForCall(i int) (context.Context, string, int, int, bool, bool) {
+	fake.fetchMergeMutex.RLock()
+	defer fake.fetchMergeMutex.RUnlock()
+	argsForCall := fake.fetchMergeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeGit) FetchMergeReturns(result1 error) {
+	fake.fetchMergeMutex.Lock()
+	defer fake.fetchMergeMutex.Unlock()
+	fake.FetchMergeStub = nil
+	fake.fetchMergeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) FetchMergeReturnsOnCall(i int, result1 error) {
+	fake.fetchMergeMutex.Lock()
+	defer fake.fetchMergeMutex.Unlock()
+	fake.FetchMergeStub = nil
+	if fake.fetchMergeReturnsOnCall == nil {
+		fake.fetchMergeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.fetchMergeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) GitCryptUnlock(arg1 context.Context, arg2 string) error {
 	fake.gitCryptUnlockMutex.Lock()
 	ret, specificReturn := fake.gitCryptUnlockReturnsOnCall[len(fake.gitCryptUnlockArgsForCall)]
 	fake.gitCryptUnlockArgsForCall = append(fake.gitCryptUnlockArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("GitCryptUnlock", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GitCryptUnlock", []interface{}{arg1, arg2})
 	fake.gitCryptUnlockMutex.Unlock()
 	if fake.GitCryptUnlockStub != nil {
-		return fake.GitCryptUnlockStub(arg1)
+		return fake.GitCryptUnlockStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -262,17 +595,17 @@ func (fake *FakeGit) GitCryptUnlockCallCount() int {
 	return len(fake.gitCryptUnlockArgsForCall)
 }
 
-func (fake *FakeGit) GitCryptUnlockCalls(stub func(string) error) {
+func (fake *FakeGit) GitCryptUnlockCalls(stub func(context.Context, string) error) {
 	fake.gitCryptUnlockMutex.Lock()
 	defer fake.gitCryptUnlockMutex.Unlock()
 	fake.GitCryptUnlockStub = stub
 }
 
-func (fake *FakeGit) GitCryptUnlockArgsForCall(i int) string {
+func (fake *FakeGit) GitCryptUnlockArgsForCall(i int) (context.Context, string) {
 	fake.gitCryptUnlockMutex.RLock()
 	defer fake.gitCryptUnlockMutex.RUnlock()
 	argsForCall := fake.gitCryptUnlockArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeGit) GitCryptUnlockReturns(result1 error) {
@@ -298,16 +631,17 @@ func (fake *FakeGit) GitCryptUnlockReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGit) Init(arg1 string) error {
+func (fake *FakeGit) Init(arg1 context.Context, arg2 string) error {
 	fake.initMutex.Lock()
 	ret, specificReturn := fake.initReturnsOnCall[len(fake.initArgsForCall)]
 	fake.initArgsForCall = append(fake.initArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("Init", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("Init", []interface{}{arg1, arg2})
 	fake.initMutex.Unlock()
 	if fake.InitStub != nil {
-		return fake.InitStub(arg1)
+		return fake.InitStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -322,17 +656,17 @@ func (fake *FakeGit) InitCallCount() int {
 	return len(fake.initArgsForCall)
 }
 
-func (fake *FakeGit) InitCalls(stub func(string) error) {
+func (fake *FakeGit) InitCalls(stub func(context.Context, string) error) {
 	fake.initMutex.Lock()
 	defer fake.initMutex.Unlock()
 	fake.InitStub = stub
 }
 
-func (fake *FakeGit) InitArgsForCall(i int) string {
+func (fake *FakeGit) InitArgsForCall(i int) (context.Context, string) {
 	fake.initMutex.RLock()
 	defer fake.initMutex.RUnlock()
 	argsForCall := fake.initArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeGit) InitReturns(result1 error) {
@@ -358,17 +692,91 @@ func (fake *FakeGit) InitReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGit) Merge(arg1 string, arg2 bool) error {
+func (fake *FakeGit) LFSPull(arg1 context.Context, arg2 string, arg3 []string, arg4 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.lFSPullMutex.Lock()
+	ret, specificReturn := fake.lFSPullReturnsOnCall[len(fake.lFSPullArgsForCall)]
+	fake.lFSPullArgsForCall = append(fake.lFSPullArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+		arg4 []string
+	}{arg1, arg2, arg3Copy, arg4Copy})
+	fake.recordInvocation("LFSPull", []interface{}{arg1, arg2, arg3Copy, arg4Copy})
+	fake.lFSPullMutex.Unlock()
+	if fake.LFSPullStub != nil {
+		return fake.LFSPullStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lFSPullReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGit) LFSPullCallCount() int {
+	fake.lFSPullMutex.RLock()
+	defer fake.lFSPullMutex.RUnlock()
+	return len(fake.lFSPullArgsForCall)
+}
+
+func (fake *FakeGit) LFSPullCalls(stub func(context.Context, string, []string, []string) error) {
+	fake.lFSPullMutex.Lock()
+	defer fake.lFSPullMutex.Unlock()
+	fake.LFSPullStub = stub
+}
+
+func (fake *FakeGit) LFSPullArgsForCall(i int) (context.Context, string, []string, []string) {
+	fake.lFSPullMutex.RLock()
+	defer fake.lFSPullMutex.RUnlock()
+	argsForCall := fake.lFSPullArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeGit) LFSPullReturns(result1 error) {
+	fake.lFSPullMutex.Lock()
+	defer fake.lFSPullMutex.Unlock()
+	fake.LFSPullStub = nil
+	fake.lFSPullReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) LFSPullReturnsOnCall(i int, result1 error) {
+	fake.lFSPullMutex.Lock()
+	defer fake.lFSPullMutex.Unlock()
+	fake.LFSPullStub = nil
+	if fake.lFSPullReturnsOnCall == nil {
+		fake.lFSPullReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.lFSPullReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) Merge(arg1 context.Context, arg2 string, arg3 bool) error {
 	fake.mergeMutex.Lock()
 	ret, specificReturn := fake.mergeReturnsOnCall[len(fake.mergeArgsForCall)]
 	fake.mergeArgsForCall = append(fake.mergeArgsForCall, struct {
-		arg1 string
-		arg2 bool
-	}{arg1, arg2})
-	fake.recordInvocation("Merge", []interface{}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Merge", []interface{}{arg1, arg2, arg3})
 	fake.mergeMutex.Unlock()
 	if fake.MergeStub != nil {
-		return fake.MergeStub(arg1, arg2)
+		return fake.MergeStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -383,17 +791,17 @@ func (fake *FakeGit) MergeCallCount() int {
 	return len(fake.mergeArgsForCall)
 }
 
-func (fake *FakeGit) MergeCalls(stub func(string, bool) error) {
+func (fake *FakeGit) MergeCalls(stub func(context.Context, string, bool) error) {
 	fake.mergeMutex.Lock()
 	defer fake.mergeMutex.Unlock()
 	fake.MergeStub = stub
 }
 
-func (fake *FakeGit) MergeArgsForCall(i int) (string, bool) {
+func (fake *FakeGit) MergeArgsForCall(i int) (context.Context, string, bool) {
 	fake.mergeMutex.RLock()
 	defer fake.mergeMutex.RUnlock()
 	argsForCall := fake.mergeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeGit) MergeReturns(result1 error) {
@@ -419,19 +827,85 @@ func (fake *FakeGit) MergeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGit) Pull(arg1 string, arg2 string, arg3 int, arg4 bool) error {
+func (fake *FakeGit) Parents(arg1 context.Context, arg2 string) ([]string, error) {
+	fake.parentsMutex.Lock()
+	ret, specificReturn := fake.parentsReturnsOnCall[len(fake.parentsArgsForCall)]
+	fake.parentsArgsForCall = append(fake.parentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("Parents", []interface{}{arg1, arg2})
+	fake.parentsMutex.Unlock()
+	if fake.ParentsStub != nil {
+		return fake.ParentsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.parentsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGit) ParentsCallCount() int {
+	fake.parentsMutex.RLock()
+	defer fake.parentsMutex.RUnlock()
+	return len(fake.parentsArgsForCall)
+}
+
+func (fake *FakeGit) ParentsCalls(stub func(context.Context, string) ([]string, error)) {
+	fake.parentsMutex.Lock()
+	defer fake.parentsMutex.Unlock()
+	fake.ParentsStub = stub
+}
+
+func (fake *FakeGit) ParentsArgsForCall(i int) (context.Context, string) {
+	fake.parentsMutex.RLock()
+	defer fake.parentsMutex.RUnlock()
+	argsForCall := fake.parentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGit) ParentsReturns(result1 []string, result2 error) {
+	fake.parentsMutex.Lock()
+	defer fake.parentsMutex.Unlock()
+	fake.ParentsStub = nil
+	fake.parentsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGit) ParentsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.parentsMutex.Lock()
+	defer fake.parentsMutex.Unlock()
+	fake.ParentsStub = nil
+	if fake.parentsReturnsOnCall == nil {
+		fake.parentsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.parentsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGit) Pull(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 bool, arg6 bool) error {
 	fake.pullMutex.Lock()
 	ret, specificReturn := fake.pullReturnsOnCall[len(fake.pullArgsForCall)]
 	fake.pullArgsForCall = append(fake.pullArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 int
-		arg4 bool
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("Pull", []interface{}{arg1, arg2, arg3, arg4})
+		arg3 string
+		arg4 int
+		arg5 bool
+		arg6 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("Pull", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.pullMutex.Unlock()
 	if fake.PullStub != nil {
-		return fake.PullStub(arg1, arg2, arg3, arg4)
+		return fake.PullStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -446,17 +920,17 @@ func (fake *FakeGit) PullCallCount() int {
 	return len(fake.pullArgsForCall)
 }
 
-func (fake *FakeGit) PullCalls(stub func(string, string, int, bool) error) {
+func (fake *FakeGit) PullCalls(stub func(context.Context, string, string, int, bool, bool) error) {
 	fake.pullMutex.Lock()
 	defer fake.pullMutex.Unlock()
 	fake.PullStub = stub
 }
 
-func (fake *FakeGit) PullArgsForCall(i int) (string, string, int, bool) {
+func (fake *FakeGit) PullArgsForCall(i int) (context.Context, string, string, int, bool, bool) {
 	fake.pullMutex.RLock()
 	defer fake.pullMutex.RUnlock()
 	argsForCall := fake.pullArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeGit) PullReturns(result1 error) {
@@ -482,18 +956,19 @@ func (fake *FakeGit) PullReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGit) Rebase(arg1 string, arg2 string, arg3 bool) error {
+func (fake *FakeGit) Rebase(arg1 context.Context, arg2 string, arg3 string, arg4 bool) error {
 	fake.rebaseMutex.Lock()
 	ret, specificReturn := fake.rebaseReturnsOnCall[len(fake.rebaseArgsForCall)]
 	fake.rebaseArgsForCall = append(fake.rebaseArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-		arg3 bool
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("Rebase", []interface{}{arg1, arg2, arg3})
+		arg3 string
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Rebase", []interface{}{arg1, arg2, arg3, arg4})
 	fake.rebaseMutex.Unlock()
 	if fake.RebaseStub != nil {
-		return fake.RebaseStub(arg1, arg2, arg3)
+		return fake.RebaseStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -508,17 +983,17 @@ func (fake *FakeGit) RebaseCallCount() int {
 	return len(fake.rebaseArgsForCall)
 }
 
-func (fake *FakeGit) RebaseCalls(stub func(string, string, bool) error) {
+func (fake *FakeGit) RebaseCalls(stub func(context.Context, string, string, bool) error) {
 	fake.rebaseMutex.Lock()
 	defer fake.rebaseMutex.Unlock()
 	fake.RebaseStub = stub
 }
 
-func (fake *FakeGit) RebaseArgsForCall(i int) (string, string, bool) {
+func (fake *FakeGit) RebaseArgsForCall(i int) (context.Context, string, string, bool) {
 	fake.rebaseMutex.RLock()
 	defer fake.rebaseMutex.RUnlock()
 	argsForCall := fake.rebaseArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeGit) RebaseReturns(result1 error) {
@@ -544,16 +1019,79 @@ func (fake *FakeGit) RebaseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGit) RevParse(arg1 string) (string, error) {
+func (fake *FakeGit) Reset(arg1 context.Context, arg2 string, arg3 bool) error {
+	fake.resetMutex.Lock()
+	ret, specificReturn := fake.resetReturnsOnCall[len(fake.resetArgsForCall)]
+	fake.resetArgsForCall = append(fake.resetArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Reset", []interface{}{arg1, arg2, arg3})
+	fake.resetMutex.Unlock()
+	if fake.ResetStub != nil {
+		return fake.ResetStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resetReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGit) ResetCallCount() int {
+	fake.resetMutex.RLock()
+	defer fake.resetMutex.RUnlock()
+	return len(fake.resetArgsForCall)
+}
+
+func (fake *FakeGit) ResetCalls(stub func(context.Context, string, bool) error) {
+	fake.resetMutex.Lock()
+	defer fake.resetMutex.Unlock()
+	fake.ResetStub = stub
+}
+
+func (fake *FakeGit) ResetArgsForCall(i int) (context.Context, string, bool) {
+	fake.resetMutex.RLock()
+	defer fake.resetMutex.RUnlock()
+	argsForCall := fake.resetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGit) ResetReturns(result1 error) {
+	fake.resetMutex.Lock()
+	defer fake.resetMutex.Unlock()
+	fake.ResetStub = nil
+	fake.resetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) ResetReturnsOnCall(i int, result1 error) {
+	fake.resetMutex.Lock()
+	defer fake.resetMutex.Unlock()
+	fake.ResetStub = nil
+	if fake.resetReturnsOnCall == nil {
+		fake.resetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) RevParse(arg1 context.Context, arg2 string) (string, error) {
 	fake.revParseMutex.Lock()
 	ret, specificReturn := fake.revParseReturnsOnCall[len(fake.revParseArgsForCall)]
 	fake.revParseArgsForCall = append(fake.revParseArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("RevParse", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RevParse", []interface{}{arg1, arg2})
 	fake.revParseMutex.Unlock()
 	if fake.RevParseStub != nil {
-		return fake.RevParseStub(arg1)
+		return fake.RevParseStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -568,17 +1106,17 @@ func (fake *FakeGit) RevParseCallCount() int {
 	return len(fake.revParseArgsForCall)
 }
 
-func (fake *FakeGit) RevParseCalls(stub func(string) (string, error)) {
+func (fake *FakeGit) RevParseCalls(stub func(context.Context, string) (string, error)) {
 	fake.revParseMutex.Lock()
 	defer fake.revParseMutex.Unlock()
 	fake.RevParseStub = stub
 }
 
-func (fake *FakeGit) RevParseArgsForCall(i int) string {
+func (fake *FakeGit) RevParseArgsForCall(i int) (context.Context, string) {
 	fake.revParseMutex.RLock()
 	defer fake.revParseMutex.RUnlock()
 	argsForCall := fake.revParseArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeGit) RevParseReturns(result1 string, result2 error) {
@@ -607,25 +1145,234 @@ func (fake *FakeGit) RevParseReturnsOnCall(i int, result1 string, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeGit) SparseCheckout(arg1 context.Context, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.sparseCheckoutMutex.Lock()
+	ret, specificReturn := fake.sparseCheckoutReturnsOnCall[len(fake.sparseCheckoutArgsForCall)]
+	fake.sparseCheckoutArgsForCall = append(fake.sparseCheckoutArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("SparseCheckout", []interface{}{arg1, arg2Copy})
+	fake.sparseCheckoutMutex.Unlock()
+	if fake.SparseCheckoutStub != nil {
+		return fake.SparseCheckoutStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sparseCheckoutReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGit) SparseCheckoutCallCount() int {
+	fake.sparseCheckoutMutex.RLock()
+	defer fake.sparseCheckoutMutex.RUnlock()
+	return len(fake.sparseCheckoutArgsForCall)
+}
+
+func (fake *FakeGit) SparseCheckoutCalls(stub func(context.Context, []string) error) {
+	fake.sparseCheckoutMutex.Lock()
+	defer fake.sparseCheckoutMutex.Unlock()
+	fake.SparseCheckoutStub = stub
+}
+
+func (fake *FakeGit) SparseCheckoutArgsForCall(i int) (context.Context, []string) {
+	fake.sparseCheckoutMutex.RLock()
+	defer fake.sparseCheckoutMutex.RUnlock()
+	argsForCall := fake.sparseCheckoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGit) SparseCheckoutReturns(result1 error) {
+	fake.sparseCheckoutMutex.Lock()
+	defer fake.sparseCheckoutMutex.Unlock()
+	fake.SparseCheckoutStub = nil
+	fake.sparseCheckoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) SparseCheckoutReturnsOnCall(i int, result1 error) {
+	fake.sparseCheckoutMutex.Lock()
+	defer fake.sparseCheckoutMutex.Unlock()
+	fake.SparseCheckoutStub = nil
+	if fake.sparseCheckoutReturnsOnCall == nil {
+		fake.sparseCheckoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sparseCheckoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) Squash(arg1 context.Context, arg2 string, arg3 string, arg4 bool) error {
+	fake.squashMutex.Lock()
+	ret, specificReturn := fake.squashReturnsOnCall[len(fake.squashArgsForCall)]
+	fake.squashArgsForCall = append(fake.squashArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Squash", []interface{}{arg1, arg2, arg3, arg4})
+	fake.squashMutex.Unlock()
+	if fake.SquashStub != nil {
+		return fake.SquashStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.squashReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGit) SquashCallCount() int {
+	fake.squashMutex.RLock()
+	defer fake.squashMutex.RUnlock()
+	return len(fake.squashArgsForCall)
+}
+
+func (fake *FakeGit) SquashCalls(stub func(context.Context, string, string, bool) error) {
+	fake.squashMutex.Lock()
+	defer fake.squashMutex.Unlock()
+	fake.SquashStub = stub
+}
+
+func (fake *FakeGit) SquashArgsForCall(i int) (context.Context, string, string, bool) {
+	fake.squashMutex.RLock()
+	defer fake.squashMutex.RUnlock()
+	argsForCall := fake.squashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeGit) SquashReturns(result1 error) {
+	fake.squashMutex.Lock()
+	defer fake.squashMutex.Unlock()
+	fake.SquashStub = nil
+	fake.squashReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) SquashReturnsOnCall(i int, result1 error) {
+	fake.squashMutex.Lock()
+	defer fake.squashMutex.Unlock()
+	fake.SquashStub = nil
+	if fake.squashReturnsOnCall == nil {
+		fake.squashReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.squashReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) VerifyCommit(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.verifyCommitMutex.Lock()
+	ret, specificReturn := fake.verifyCommitReturnsOnCall[len(fake.verifyCommitArgsForCall)]
+	fake.verifyCommitArgsForCall = append(fake.verifyCommitArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("VerifyCommit", []interface{}{arg1, arg2, arg3})
+	fake.verifyCommitMutex.Unlock()
+	if fake.VerifyCommitStub != nil {
+		return fake.VerifyCommitStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.verifyCommitReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGit) VerifyCommitCallCount() int {
+	fake.verifyCommitMutex.RLock()
+	defer fake.verifyCommitMutex.RUnlock()
+	return len(fake.verifyCommitArgsForCall)
+}
+
+func (fake *FakeGit) VerifyCommitCalls(stub func(context.Context, string, string) error) {
+	fake.verifyCommitMutex.Lock()
+	defer fake.verifyCommitMutex.Unlock()
+	fake.VerifyCommitStub = stub
+}
+
+func (fake *FakeGit) VerifyCommitArgsForCall(i int) (context.Context, string, string) {
+	fake.verifyCommitMutex.RLock()
+	defer fake.verifyCommitMutex.RUnlock()
+	argsForCall := fake.verifyCommitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGit) VerifyCommitReturns(result1 error) {
+	fake.verifyCommitMutex.Lock()
+	defer fake.verifyCommitMutex.Unlock()
+	fake.VerifyCommitStub = nil
+	fake.verifyCommitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGit) VerifyCommitReturnsOnCall(i int, result1 error) {
+	fake.verifyCommitMutex.Lock()
+	defer fake.verifyCommitMutex.Unlock()
+	fake.VerifyCommitStub = nil
+	if fake.verifyCommitReturnsOnCall == nil {
+		fake.verifyCommitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyCommitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeGit) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkoutMutex.RLock()
 	defer fake.checkoutMutex.RUnlock()
+	fake.conflictsMutex.RLock()
+	defer fake.conflictsMutex.RUnlock()
 	fake.fetchMutex.RLock()
 	defer fake.fetchMutex.RUnlock()
+	fake.fetchCommitMutex.RLock()
+	defer fake.fetchCommitMutex.RUnlock()
+	fake.fetchMergeMutex.RLock()
+	defer fake.fetchMergeMutex.RUnlock()
 	fake.gitCryptUnlockMutex.RLock()
 	defer fake.gitCryptUnlockMutex.RUnlock()
 	fake.initMutex.RLock()
 	defer fake.initMutex.RUnlock()
+	fake.lFSPullMutex.RLock()
+	defer fake.lFSPullMutex.RUnlock()
 	fake.mergeMutex.RLock()
 	defer fake.mergeMutex.RUnlock()
+	fake.parentsMutex.RLock()
+	defer fake.parentsMutex.RUnlock()
 	fake.pullMutex.RLock()
 	defer fake.pullMutex.RUnlock()
 	fake.rebaseMutex.RLock()
 	defer fake.rebaseMutex.RUnlock()
+	fake.resetMutex.RLock()
+	defer fake.resetMutex.RUnlock()
 	fake.revParseMutex.RLock()
 	defer fake.revParseMutex.RUnlock()
+	fake.sparseCheckoutMutex.RLock()
+	defer fake.sparseCheckoutMutex.RUnlock()
+	fake.squashMutex.RLock()
+	defer fake.squashMutex.RUnlock()
+	fake.verifyCommitMutex.RLock()
+	defer fake.verifyCommitMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
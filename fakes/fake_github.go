@@ -2,16 +2,50 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	resource "github.com/telia-oss/github-pr-resource"
 )
 
 type FakeGithub struct {
-	DeletePreviousCommentsStub        func(string) error
+	CreateDeploymentStub        func(context.Context, string, string, string, bool) (int64, error)
+	createDeploymentMutex       sync.RWMutex
+	createDeploymentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 bool
+	}
+	createDeploymentReturns struct {
+		result1 int64
+		result2 error
+	}
+	createDeploymentReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	CreateDeploymentStatusStub        func(context.Context, int64, string, string, string) error
+	createDeploymentStatusMutex       sync.RWMutex
+	createDeploymentStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	createDeploymentStatusReturns struct {
+		result1 error
+	}
+	createDeploymentStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeletePreviousCommentsStub        func(context.Context, string) error
 	deletePreviousCommentsMutex       sync.RWMutex
 	deletePreviousCommentsArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	deletePreviousCommentsReturns struct {
 		result1 error
@@ -19,11 +53,12 @@ type FakeGithub struct {
 	deletePreviousCommentsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetChangedFilesStub        func(string, string) ([]resource.ChangedFileObject, error)
+	GetChangedFilesStub        func(context.Context, string, string) ([]resource.ChangedFileObject, error)
 	getChangedFilesMutex       sync.RWMutex
 	getChangedFilesArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	getChangedFilesReturns struct {
 		result1 []resource.ChangedFileObject
@@ -33,11 +68,71 @@ type FakeGithub struct {
 		result1 []resource.ChangedFileObject
 		result2 error
 	}
-	GetPullRequestStub        func(string, string) (*resource.PullRequest, error)
+	GetCommitStatusesStub        func(context.Context, string, string) (map[string]string, error)
+	getCommitStatusesMutex       sync.RWMutex
+	getCommitStatusesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getCommitStatusesReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getCommitStatusesReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	GetDiffStub        func(context.Context, string, string) (string, error)
+	getDiffMutex       sync.RWMutex
+	getDiffArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getDiffReturns struct {
+		result1 string
+		result2 error
+	}
+	getDiffReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetLatestDeploymentStub        func(context.Context, string, string) (int64, error)
+	getLatestDeploymentMutex       sync.RWMutex
+	getLatestDeploymentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getLatestDeploymentReturns struct {
+		result1 int64
+		result2 error
+	}
+	getLatestDeploymentReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	GetPatchStub        func(context.Context, string) (string, error)
+	getPatchMutex       sync.RWMutex
+	getPatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getPatchReturns struct {
+		result1 string
+		result2 error
+	}
+	getPatchReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetPullRequestStub        func(context.Context, string, string) (*resource.PullRequest, error)
 	getPullRequestMutex       sync.RWMutex
 	getPullRequestArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	getPullRequestReturns struct {
 		result1 *resource.PullRequest
@@ -47,10 +142,11 @@ type FakeGithub struct {
 		result1 *resource.PullRequest
 		result2 error
 	}
-	ListModifiedFilesStub        func(int) ([]string, error)
+	ListModifiedFilesStub        func(context.Context, int) ([]string, error)
 	listModifiedFilesMutex       sync.RWMutex
 	listModifiedFilesArgsForCall []struct {
-		arg1 int
+		arg1 context.Context
+		arg2 int
 	}
 	listModifiedFilesReturns struct {
 		result1 []string
@@ -60,9 +156,10 @@ type FakeGithub struct {
 		result1 []string
 		result2 error
 	}
-	ListOpenPullRequestsStub        func() ([]*resource.PullRequest, error)
+	ListOpenPullRequestsStub        func(context.Context) ([]*resource.PullRequest, error)
 	listOpenPullRequestsMutex       sync.RWMutex
 	listOpenPullRequestsArgsForCall []struct {
+		arg1 context.Context
 	}
 	listOpenPullRequestsReturns struct {
 		result1 []*resource.PullRequest
@@ -72,11 +169,12 @@ type FakeGithub struct {
 		result1 []*resource.PullRequest
 		result2 error
 	}
-	PostCommentStub        func(string, string) error
+	PostCommentStub        func(context.Context, string, string) error
 	postCommentMutex       sync.RWMutex
 	postCommentArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	postCommentReturns struct {
 		result1 error
@@ -84,15 +182,16 @@ type FakeGithub struct {
 	postCommentReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UpdateCommitStatusStub        func(string, string, string, string, string, string) error
+	UpdateCommitStatusStub        func(context.Context, string, string, string, string, string, string) error
 	updateCommitStatusMutex       sync.RWMutex
 	updateCommitStatusArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
 		arg6 string
+		arg7 string
 	}
 	updateCommitStatusReturns struct {
 		result1 error
@@ -104,16 +203,148 @@ type FakeGithub struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeGithub) DeletePreviousComments(arg1 string) error {
+func (fake *FakeGithub) CreateDeployment(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 bool) (int64, error) {
+	fake.createDeploymentMutex.Lock()
+	ret, specificReturn := fake.createDeploymentReturnsOnCall[len(fake.createDeploymentArgsForCall)]
+	fake.createDeploymentArgsForCall = append(fake.createDeploymentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 bool
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("CreateDeployment", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.createDeploymentMutex.Unlock()
+	if fake.CreateDeploymentStub != nil {
+		return fake.CreateDeploymentStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createDeploymentReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGithub) CreateDeploymentCallCount() int {
+	fake.createDeploymentMutex.RLock()
+	defer fake.createDeploymentMutex.RUnlock()
+	return len(fake.createDeploymentArgsForCall)
+}
+
+func (fake *FakeGithub) CreateDeploymentCalls(stub func(context.Context, string, string, string, bool) (int64, error)) {
+	fake.createDeploymentMutex.Lock()
+	defer fake.createDeploymentMutex.Unlock()
+	fake.CreateDeploymentStub = stub
+}
+
+func (fake *FakeGithub) CreateDeploymentArgsForCall(i int) (context.Context, string, string, string, bool) {
+	fake.createDeploymentMutex.RLock()
+	defer fake.createDeploymentMutex.RUnlock()
+	argsForCall := fake.createDeploymentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeGithub) CreateDeploymentReturns(result1 int64, result2 error) {
+	fake.createDeploymentMutex.Lock()
+	defer fake.createDeploymentMutex.Unlock()
+	fake.CreateDeploymentStub = nil
+	fake.createDeploymentReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) CreateDeploymentReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createDeploymentMutex.Lock()
+	defer fake.createDeploymentMutex.Unlock()
+	fake.CreateDeploymentStub = nil
+	if fake.createDeploymentReturnsOnCall == nil {
+		fake.createDeploymentReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.createDeploymentReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) CreateDeploymentStatus(arg1 context.Context, arg2 int64, arg3 string, arg4 string, arg5 string) error {
+	fake.createDeploymentStatusMutex.Lock()
+	ret, specificReturn := fake.createDeploymentStatusReturnsOnCall[len(fake.createDeploymentStatusArgsForCall)]
+	fake.createDeploymentStatusArgsForCall = append(fake.createDeploymentStatusArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("CreateDeploymentStatus", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.createDeploymentStatusMutex.Unlock()
+	if fake.CreateDeploymentStatusStub != nil {
+		return fake.CreateDeploymentStatusStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createDeploymentStatusReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGithub) CreateDeploymentStatusCallCount() int {
+	fake.createDeploymentStatusMutex.RLock()
+	defer fake.createDeploymentStatusMutex.RUnlock()
+	return len(fake.createDeploymentStatusArgsForCall)
+}
+
+func (fake *FakeGithub) CreateDeploymentStatusCalls(stub func(context.Context, int64, string, string, string) error) {
+	fake.createDeploymentStatusMutex.Lock()
+	defer fake.createDeploymentStatusMutex.Unlock()
+	fake.CreateDeploymentStatusStub = stub
+}
+
+func (fake *FakeGithub) CreateDeploymentStatusArgsForCall(i int) (context.Context, int64, string, string, string) {
+	fake.createDeploymentStatusMutex.RLock()
+	defer fake.createDeploymentStatusMutex.RUnlock()
+	argsForCall := fake.createDeploymentStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeGithub) CreateDeploymentStatusReturns(result1 error) {
+	fake.createDeploymentStatusMutex.Lock()
+	defer fake.createDeploymentStatusMutex.Unlock()
+	fake.CreateDeploymentStatusStub = nil
+	fake.createDeploymentStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGithub) CreateDeploymentStatusReturnsOnCall(i int, result1 error) {
+	fake.createDeploymentStatusMutex.Lock()
+	defer fake.createDeploymentStatusMutex.Unlock()
+	fake.CreateDeploymentStatusStub = nil
+	if fake.createDeploymentStatusReturnsOnCall == nil {
+		fake.createDeploymentStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createDeploymentStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGithub) DeletePreviousComments(arg1 context.Context, arg2 string) error {
 	fake.deletePreviousCommentsMutex.Lock()
 	ret, specificReturn := fake.deletePreviousCommentsReturnsOnCall[len(fake.deletePreviousCommentsArgsForCall)]
 	fake.deletePreviousCommentsArgsForCall = append(fake.deletePreviousCommentsArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("DeletePreviousComments", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeletePreviousComments", []interface{}{arg1, arg2})
 	fake.deletePreviousCommentsMutex.Unlock()
 	if fake.DeletePreviousCommentsStub != nil {
-		return fake.DeletePreviousCommentsStub(arg1)
+		return fake.DeletePreviousCommentsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -128,17 +359,17 @@ func (fake *FakeGithub) DeletePreviousCommentsCallCount() int {
 	return len(fake.deletePreviousCommentsArgsForCall)
 }
 
-func (fake *FakeGithub) DeletePreviousCommentsCalls(stub func(string) error) {
+func (fake *FakeGithub) DeletePreviousCommentsCalls(stub func(context.Context, string) error) {
 	fake.deletePreviousCommentsMutex.Lock()
 	defer fake.deletePreviousCommentsMutex.Unlock()
 	fake.DeletePreviousCommentsStub = stub
 }
 
-func (fake *FakeGithub) DeletePreviousCommentsArgsForCall(i int) string {
+func (fake *FakeGithub) DeletePreviousCommentsArgsForCall(i int) (context.Context, string) {
 	fake.deletePreviousCommentsMutex.RLock()
 	defer fake.deletePreviousCommentsMutex.RUnlock()
 	argsForCall := fake.deletePreviousCommentsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeGithub) DeletePreviousCommentsReturns(result1 error) {
@@ -164,17 +395,18 @@ func (fake *FakeGithub) DeletePreviousCommentsReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeGithub) GetChangedFiles(arg1 string, arg2 string) ([]resource.ChangedFileObject, error) {
+func (fake *FakeGithub) GetChangedFiles(arg1 context.Context, arg2 string, arg3 string) ([]resource.ChangedFileObject, error) {
 	fake.getChangedFilesMutex.Lock()
 	ret, specificReturn := fake.getChangedFilesReturnsOnCall[len(fake.getChangedFilesArgsForCall)]
 	fake.getChangedFilesArgsForCall = append(fake.getChangedFilesArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("GetChangedFiles", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetChangedFiles", []interface{}{arg1, arg2, arg3})
 	fake.getChangedFilesMutex.Unlock()
 	if fake.GetChangedFilesStub != nil {
-		return fake.GetChangedFilesStub(arg1, arg2)
+		return fake.GetChangedFilesStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -189,17 +421,17 @@ func (fake *FakeGithub) GetChangedFilesCallCount() int {
 	return len(fake.getChangedFilesArgsForCall)
 }
 
-func (fake *FakeGithub) GetChangedFilesCalls(stub func(string, string) ([]resource.ChangedFileObject, error)) {
+func (fake *FakeGithub) GetChangedFilesCalls(stub func(context.Context, string, string) ([]resource.ChangedFileObject, error)) {
 	fake.getChangedFilesMutex.Lock()
 	defer fake.getChangedFilesMutex.Unlock()
 	fake.GetChangedFilesStub = stub
 }
 
-func (fake *FakeGithub) GetChangedFilesArgsForCall(i int) (string, string) {
+func (fake *FakeGithub) GetChangedFilesArgsForCall(i int) (context.Context, string, string) {
 	fake.getChangedFilesMutex.RLock()
 	defer fake.getChangedFilesMutex.RUnlock()
 	argsForCall := fake.getChangedFilesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeGithub) GetChangedFilesReturns(result1 []resource.ChangedFileObject, result2 error) {
@@ -228,17 +460,277 @@ func (fake *FakeGithub) GetChangedFilesReturnsOnCall(i int, result1 []resource.C
 	}{result1, result2}
 }
 
-func (fake *FakeGithub) GetPullRequest(arg1 string, arg2 string) (*resource.PullRequest, error) {
+func (fake *FakeGithub) GetCommitStatuses(arg1 context.Context, arg2 string, arg3 string) (map[string]string, error) {
+	fake.getCommitStatusesMutex.Lock()
+	ret, specificReturn := fake.getCommitStatusesReturnsOnCall[len(fake.getCommitStatusesArgsForCall)]
+	fake.getCommitStatusesArgsForCall = append(fake.getCommitStatusesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetCommitStatuses", []interface{}{arg1, arg2, arg3})
+	fake.getCommitStatusesMutex.Unlock()
+	if fake.GetCommitStatusesStub != nil {
+		return fake.GetCommitStatusesStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getCommitStatusesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGithub) GetCommitStatusesCallCount() int {
+	fake.getCommitStatusesMutex.RLock()
+	defer fake.getCommitStatusesMutex.RUnlock()
+	return len(fake.getCommitStatusesArgsForCall)
+}
+
+func (fake *FakeGithub) GetCommitStatusesCalls(stub func(context.Context, string, string) (map[string]string, error)) {
+	fake.getCommitStatusesMutex.Lock()
+	defer fake.getCommitStatusesMutex.Unlock()
+	fake.GetCommitStatusesStub = stub
+}
+
+func (fake *FakeGithub) GetCommitStatusesArgsForCall(i int) (context.Context, string, string) {
+	fake.getCommitStatusesMutex.RLock()
+	defer fake.getCommitStatusesMutex.RUnlock()
+	argsForCall := fake.getCommitStatusesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGithub) GetCommitStatusesReturns(result1 map[string]string, result2 error) {
+	fake.getCommitStatusesMutex.Lock()
+	defer fake.getCommitStatusesMutex.Unlock()
+	fake.GetCommitStatusesStub = nil
+	fake.getCommitStatusesReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetCommitStatusesReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.getCommitStatusesMutex.Lock()
+	defer fake.getCommitStatusesMutex.Unlock()
+	fake.GetCommitStatusesStub = nil
+	if fake.getCommitStatusesReturnsOnCall == nil {
+		fake.getCommitStatusesReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getCommitStatusesReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetDiff(arg1 context.Context, arg2 string, arg3 string) (string, error) {
+	fake.getDiffMutex.Lock()
+	ret, specificReturn := fake.getDiffReturnsOnCall[len(fake.getDiffArgsForCall)]
+	fake.getDiffArgsForCall = append(fake.getDiffArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetDiff", []interface{}{arg1, arg2, arg3})
+	fake.getDiffMutex.Unlock()
+	if fake.GetDiffStub != nil {
+		return fake.GetDiffStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDiffReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGithub) GetDiffCallCount() int {
+	fake.getDiffMutex.RLock()
+	defer fake.getDiffMutex.RUnlock()
+	return len(fake.getDiffArgsForCall)
+}
+
+func (fake *FakeGithub) GetDiffCalls(stub func(context.Context, string, string) (string, error)) {
+	fake.getDiffMutex.Lock()
+	defer fake.getDiffMutex.Unlock()
+	fake.GetDiffStub = stub
+}
+
+func (fake *FakeGithub) GetDiffArgsForCall(i int) (context.Context, string, string) {
+	fake.getDiffMutex.RLock()
+	defer fake.getDiffMutex.RUnlock()
+	argsForCall := fake.getDiffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGithub) GetDiffReturns(result1 string, result2 error) {
+	fake.getDiffMutex.Lock()
+	defer fake.getDiffMutex.Unlock()
+	fake.GetDiffStub = nil
+	fake.getDiffReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetDiffReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDiffMutex.Lock()
+	defer fake.getDiffMutex.Unlock()
+	fake.GetDiffStub = nil
+	if fake.getDiffReturnsOnCall == nil {
+		fake.getDiffReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDiffReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetLatestDeployment(arg1 context.Context, arg2 string, arg3 string) (int64, error) {
+	fake.getLatestDeploymentMutex.Lock()
+	ret, specificReturn := fake.getLatestDeploymentReturnsOnCall[len(fake.getLatestDeploymentArgsForCall)]
+	fake.getLatestDeploymentArgsForCall = append(fake.getLatestDeploymentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetLatestDeployment", []interface{}{arg1, arg2, arg3})
+	fake.getLatestDeploymentMutex.Unlock()
+	if fake.GetLatestDeploymentStub != nil {
+		return fake.GetLatestDeploymentStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getLatestDeploymentReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGithub) GetLatestDeploymentCallCount() int {
+	fake.getLatestDeploymentMutex.RLock()
+	defer fake.getLatestDeploymentMutex.RUnlock()
+	return len(fake.getLatestDeploymentArgsForCall)
+}
+
+func (fake *FakeGithub) GetLatestDeploymentCalls(stub func(context.Context, string, string) (int64, error)) {
+	fake.getLatestDeploymentMutex.Lock()
+	defer fake.getLatestDeploymentMutex.Unlock()
+	fake.GetLatestDeploymentStub = stub
+}
+
+func (fake *FakeGithub) GetLatestDeploymentArgsForCall(i int) (context.Context, string, string) {
+	fake.getLatestDeploymentMutex.RLock()
+	defer fake.getLatestDeploymentMutex.RUnlock()
+	argsForCall := fake.getLatestDeploymentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGithub) GetLatestDeploymentReturns(result1 int64, result2 error) {
+	fake.getLatestDeploymentMutex.Lock()
+	defer fake.getLatestDeploymentMutex.Unlock()
+	fake.GetLatestDeploymentStub = nil
+	fake.getLatestDeploymentReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetLatestDeploymentReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.getLatestDeploymentMutex.Lock()
+	defer fake.getLatestDeploymentMutex.Unlock()
+	fake.GetLatestDeploymentStub = nil
+	if fake.getLatestDeploymentReturnsOnCall == nil {
+		fake.getLatestDeploymentReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.getLatestDeploymentReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetPatch(arg1 context.Context, arg2 string) (string, error) {
+	fake.getPatchMutex.Lock()
+	ret, specificReturn := fake.getPatchReturnsOnCall[len(fake.getPatchArgsForCall)]
+	fake.getPatchArgsForCall = append(fake.getPatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetPatch", []interface{}{arg1, arg2})
+	fake.getPatchMutex.Unlock()
+	if fake.GetPatchStub != nil {
+		return fake.GetPatchStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPatchReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGithub) GetPatchCallCount() int {
+	fake.getPatchMutex.RLock()
+	defer fake.getPatchMutex.RUnlock()
+	return len(fake.getPatchArgsForCall)
+}
+
+func (fake *FakeGithub) GetPatchCalls(stub func(context.Context, string) (string, error)) {
+	fake.getPatchMutex.Lock()
+	defer fake.getPatchMutex.Unlock()
+	fake.GetPatchStub = stub
+}
+
+func (fake *FakeGithub) GetPatchArgsForCall(i int) (context.Context, string) {
+	fake.getPatchMutex.RLock()
+	defer fake.getPatchMutex.RUnlock()
+	argsForCall := fake.getPatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGithub) GetPatchReturns(result1 string, result2 error) {
+	fake.getPatchMutex.Lock()
+	defer fake.getPatchMutex.Unlock()
+	fake.GetPatchStub = nil
+	fake.getPatchReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetPatchReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getPatchMutex.Lock()
+	defer fake.getPatchMutex.Unlock()
+	fake.GetPatchStub = nil
+	if fake.getPatchReturnsOnCall == nil {
+		fake.getPatchReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getPatchReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithub) GetPullRequest(arg1 context.Context, arg2 string, arg3 string) (*resource.PullRequest, error) {
 	fake.getPullRequestMutex.Lock()
 	ret, specificReturn := fake.getPullRequestReturnsOnCall[len(fake.getPullRequestArgsForCall)]
 	fake.getPullRequestArgsForCall = append(fake.getPullRequestArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("GetPullRequest", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetPullRequest", []interface{}{arg1, arg2, arg3})
 	fake.getPullRequestMutex.Unlock()
 	if fake.GetPullRequestStub != nil {
-		return fake.GetPullRequestStub(arg1, arg2)
+		return fake.GetPullRequestStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -253,17 +745,17 @@ func (fake *FakeGithub) GetPullRequestCallCount() int {
 	return len(fake.getPullRequestArgsForCall)
 }
 
-func (fake *FakeGithub) GetPullRequestCalls(stub func(string, string) (*resource.PullRequest, error)) {
+func (fake *FakeGithub) GetPullRequestCalls(stub func(context.Context, string, string) (*resource.PullRequest, error)) {
 	fake.getPullRequestMutex.Lock()
 	defer fake.getPullRequestMutex.Unlock()
 	fake.GetPullRequestStub = stub
 }
 
-func (fake *FakeGithub) GetPullRequestArgsForCall(i int) (string, string) {
+func (fake *FakeGithub) GetPullRequestArgsForCall(i int) (context.Context, string, string) {
 	fake.getPullRequestMutex.RLock()
 	defer fake.getPullRequestMutex.RUnlock()
 	argsForCall := fake.getPullRequestArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeGithub) GetPullRequestReturns(result1 *resource.PullRequest, result2 error) {
@@ -292,16 +784,17 @@ func (fake *FakeGithub) GetPullRequestReturnsOnCall(i int, result1 *resource.Pul
 	}{result1, result2}
 }
 
-func (fake *FakeGithub) ListModifiedFiles(arg1 int) ([]string, error) {
+func (fake *FakeGithub) ListModifiedFiles(arg1 context.Context, arg2 int) ([]string, error) {
 	fake.listModifiedFilesMutex.Lock()
 	ret, specificReturn := fake.listModifiedFilesReturnsOnCall[len(fake.listModifiedFilesArgsForCall)]
 	fake.listModifiedFilesArgsForCall = append(fake.listModifiedFilesArgsForCall, struct {
-		arg1 int
-	}{arg1})
-	fake.recordInvocation("ListModifiedFiles", []interface{}{arg1})
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("ListModifiedFiles", []interface{}{arg1, arg2})
 	fake.listModifiedFilesMutex.Unlock()
 	if fake.ListModifiedFilesStub != nil {
-		return fake.ListModifiedFilesStub(arg1)
+		return fake.ListModifiedFilesStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -316,17 +809,17 @@ func (fake *FakeGithub) ListModifiedFilesCallCount() int {
 	return len(fake.listModifiedFilesArgsForCall)
 }
 
-func (fake *FakeGithub) ListModifiedFilesCalls(stub func(int) ([]string, error)) {
+func (fake *FakeGithub) ListModifiedFilesCalls(stub func(context.Context, int) ([]string, error)) {
 	fake.listModifiedFilesMutex.Lock()
 	defer fake.listModifiedFilesMutex.Unlock()
 	fake.ListModifiedFilesStub = stub
 }
 
-func (fake *FakeGithub) ListModifiedFilesArgsForCall(i int) int {
+func (fake *FakeGithub) ListModifiedFilesArgsForCall(i int) (context.Context, int) {
 	fake.listModifiedFilesMutex.RLock()
 	defer fake.listModifiedFilesMutex.RUnlock()
 	argsForCall := fake.listModifiedFilesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeGithub) ListModifiedFilesReturns(result1 []string, result2 error) {
@@ -355,15 +848,16 @@ func (fake *FakeGithub) ListModifiedFilesReturnsOnCall(i int, result1 []string,
 	}{result1, result2}
 }
 
-func (fake *FakeGithub) ListOpenPullRequests() ([]*resource.PullRequest, error) {
+func (fake *FakeGithub) ListOpenPullRequests(arg1 context.Context) ([]*resource.PullRequest, error) {
 	fake.listOpenPullRequestsMutex.Lock()
 	ret, specificReturn := fake.listOpenPullRequestsReturnsOnCall[len(fake.listOpenPullRequestsArgsForCall)]
 	fake.listOpenPullRequestsArgsForCall = append(fake.listOpenPullRequestsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListOpenPullRequests", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("ListOpenPullRequests", []interface{}{arg1})
 	fake.listOpenPullRequestsMutex.Unlock()
 	if fake.ListOpenPullRequestsStub != nil {
-		return fake.ListOpenPullRequestsStub()
+		return fake.ListOpenPullRequestsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -378,12 +872,19 @@ func (fake *FakeGithub) ListOpenPullRequestsCallCount() int {
 	return len(fake.listOpenPullRequestsArgsForCall)
 }
 
-func (fake *FakeGithub) ListOpenPullRequestsCalls(stub func() ([]*resource.PullRequest, error)) {
+func (fake *FakeGithub) ListOpenPullRequestsCalls(stub func(context.Context) ([]*resource.PullRequest, error)) {
 	fake.listOpenPullRequestsMutex.Lock()
 	defer fake.listOpenPullRequestsMutex.Unlock()
 	fake.ListOpenPullRequestsStub = stub
 }
 
+func (fake *FakeGithub) ListOpenPullRequestsArgsForCall(i int) context.Context {
+	fake.listOpenPullRequestsMutex.RLock()
+	defer fake.listOpenPullRequestsMutex.RUnlock()
+	argsForCall := fake.listOpenPullRequestsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeGithub) ListOpenPullRequestsReturns(result1 []*resource.PullRequest, result2 error) {
 	fake.listOpenPullRequestsMutex.Lock()
 	defer fake.listOpenPullRequestsMutex.Unlock()
@@ -410,17 +911,18 @@ func (fake *FakeGithub) ListOpenPullRequestsReturnsOnCall(i int, result1 []*reso
 	}{result1, result2}
 }
 
-func (fake *FakeGithub) PostComment(arg1 string, arg2 string) error {
+func (fake *FakeGithub) PostComment(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.postCommentMutex.Lock()
 	ret, specificReturn := fake.postCommentReturnsOnCall[len(fake.postCommentArgsForCall)]
 	fake.postCommentArgsForCall = append(fake.postCommentArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("PostComment", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("PostComment", []interface{}{arg1, arg2, arg3})
 	fake.postCommentMutex.Unlock()
 	if fake.PostCommentStub != nil {
-		return fake.PostCommentStub(arg1, arg2)
+		return fake.PostCommentStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -435,17 +937,17 @@ func (fake *FakeGithub) PostCommentCallCount() int {
 	return len(fake.postCommentArgsForCall)
 }
 
-func (fake *FakeGithub) PostCommentCalls(stub func(string, string) error) {
+func (fake *FakeGithub) PostCommentCalls(stub func(context.Context, string, string) error) {
 	fake.postCommentMutex.Lock()
 	defer fake.postCommentMutex.Unlock()
 	fake.PostCommentStub = stub
 }
 
-func (fake *FakeGithub) PostCommentArgsForCall(i int) (string, string) {
+func (fake *FakeGithub) PostCommentArgsForCall(i int) (context.Context, string, string) {
 	fake.postCommentMutex.RLock()
 	defer fake.postCommentMutex.RUnlock()
 	argsForCall := fake.postCommentArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeGithub) PostCommentReturns(result1 error) {
@@ -471,21 +973,22 @@ func (fake *FakeGithub) PostCommentReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGithub) UpdateCommitStatus(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
+func (fake *FakeGithub) UpdateCommitStatus(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string, arg7 string) error {
 	fake.updateCommitStatusMutex.Lock()
 	ret, specificReturn := fake.updateCommitStatusReturnsOnCall[len(fake.updateCommitStatusArgsForCall)]
 	fake.updateCommitStatusArgsForCall = append(fake.updateCommitStatusArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
 		arg5 string
 		arg6 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("UpdateCommitStatus", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg7 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("UpdateCommitStatus", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.updateCommitStatusMutex.Unlock()
 	if fake.UpdateCommitStatusStub != nil {
-		return fake.UpdateCommitStatusStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.UpdateCommitStatusStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1
@@ -500,17 +1003,17 @@ func (fake *FakeGithub) UpdateCommitStatusCallCount() int {
 	return len(fake.updateCommitStatusArgsForCall)
 }
 
-func (fake *FakeGithub) UpdateCommitStatusCalls(stub func(string, string, string, string, string, string) error) {
+func (fake *FakeGithub) UpdateCommitStatusCalls(stub func(context.Context, string, string, string, string, string, string) error) {
 	fake.updateCommitStatusMutex.Lock()
 	defer fake.updateCommitStatusMutex.Unlock()
 	fake.UpdateCommitStatusStub = stub
 }
 
-func (fake *FakeGithub) UpdateCommitStatusArgsForCall(i int) (string, string, string, string, string, string) {
+func (fake *FakeGithub) UpdateCommitStatusArgsForCall(i int) (context.Context, string, string, string, string, string, string) {
 	fake.updateCommitStatusMutex.RLock()
 	defer fake.updateCommitStatusMutex.RUnlock()
 	argsForCall := fake.updateCommitStatusArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
 }
 
 func (fake *FakeGithub) UpdateCommitStatusReturns(result1 error) {
@@ -539,10 +1042,22 @@ func (fake *FakeGithub) UpdateCommitStatusReturnsOnCall(i int, result1 error) {
 func (fake *FakeGithub) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createDeploymentMutex.RLock()
+	defer fake.createDeploymentMutex.RUnlock()
+	fake.createDeploymentStatusMutex.RLock()
+	defer fake.createDeploymentStatusMutex.RUnlock()
 	fake.deletePreviousCommentsMutex.RLock()
 	defer fake.deletePreviousCommentsMutex.RUnlock()
 	fake.getChangedFilesMutex.RLock()
 	defer fake.getChangedFilesMutex.RUnlock()
+	fake.getCommitStatusesMutex.RLock()
+	defer fake.getCommitStatusesMutex.RUnlock()
+	fake.getDiffMutex.RLock()
+	defer fake.getDiffMutex.RUnlock()
+	fake.getLatestDeploymentMutex.RLock()
+	defer fake.getLatestDeploymentMutex.RUnlock()
+	fake.getPatchMutex.RLock()
+	defer fake.getPatchMutex.RUnlock()
 	fake.getPullRequestMutex.RLock()
 	defer fake.getPullRequestMutex.RUnlock()
 	fake.listModifiedFilesMutex.RLock()
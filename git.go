@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io"
@@ -11,19 +12,29 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 )
 
 // Git interface for testing purposes.
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -o fakes/fake_git.go . Git
 type Git interface {
-	Init(string) error
-	Pull(string, string, int, bool) error
-	RevParse(string) (string, error)
-	Fetch(string, int, int, bool) error
-	Checkout(string, string, bool) error
-	Merge(string, bool) error
-	Rebase(string, string, bool) error
-	GitCryptUnlock(string) error
+	Init(context.Context, string) error
+	SparseCheckout(context.Context, []string) error
+	Pull(context.Context, string, string, int, bool, bool) error
+	RevParse(context.Context, string) (string, error)
+	Fetch(context.Context, string, int, int, bool, bool) error
+	FetchMerge(context.Context, string, int, int, bool, bool) error
+	FetchCommit(context.Context, string, string, int, bool, bool) error
+	Parents(context.Context, string) ([]string, error)
+	Reset(context.Context, string, bool) error
+	Checkout(context.Context, string, string, bool) error
+	Merge(context.Context, string, bool) error
+	Rebase(context.Context, string, string, bool) error
+	Squash(context.Context, string, string, bool) error
+	Conflicts(context.Context) ([]string, error)
+	LFSPull(context.Context, string, []string, []string) error
+	GitCryptUnlock(context.Context, string) error
+	VerifyCommit(context.Context, string, string) error
 }
 
 // NewGitClient ...
@@ -31,133 +42,256 @@ func NewGitClient(source *Source, dir string, output io.Writer) (*GitClient, err
 	if source.SkipSSLVerification {
 		os.Setenv("GIT_SSL_NO_VERIFY", "true")
 	}
+	var sshCommand, sshDir string
+	if source.PrivateKey != "" {
+		var err error
+		if sshCommand, sshDir, err = newSSHCommand(source); err != nil {
+			return nil, err
+		}
+	}
 	return &GitClient{
 		AccessToken: source.AccessToken,
+		SSHCommand:  sshCommand,
+		CABundle:    source.CABundle,
+		ProxyURL:    source.ProxyURL,
 		Directory:   dir,
 		Output:      output,
+		sshDir:      sshDir,
 	}, nil
 }
 
 // GitClient ...
 type GitClient struct {
 	AccessToken string
+	SSHCommand  string
+	CABundle    string
+	ProxyURL    string
 	Directory   string
 	Output      io.Writer
+
+	// sshDir is the temporary directory containing the private key (if any).
+	sshDir string
 }
 
-func (g *GitClient) command(name string, arg ...string) *exec.Cmd {
-	cmd := exec.Command(name, arg...)
+// Close removes the private key written for SSH connections.
+func (g *GitClient) Close() error {
+	if g.sshDir == "" {
+		return nil
+	}
+	return os.RemoveAll(g.sshDir)
+}
+
+func (g *GitClient) command(ctx context.Context, name string, arg ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, arg...)
 	cmd.Dir = g.Directory
 	cmd.Stdout = g.Output
 	cmd.Stderr = g.Output
-	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env,
+	// The access token is also provided when using SSH, since e.g. submodules might still use HTTPS.
+	cmd.Env = append(os.Environ(),
 		"X_OAUTH_BASIC_TOKEN="+g.AccessToken,
 		"GIT_ASKPASS=/usr/local/bin/askpass.sh")
+	if g.SSHCommand != "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND="+g.SSHCommand)
+	}
 	return cmd
 }
 
 // Init ...
-func (g *GitClient) Init(branch string) error {
-	if err := g.command("git", "init").Run(); err != nil {
-		return fmt.Errorf("init failed: %s", err)
+func (g *GitClient) Init(ctx context.Context, branch string) error {
+	if err := g.command(ctx, "git", "init").Run(); err != nil {
+		return timeoutError(ctx, "git init", fmt.Errorf("init failed: %s", err))
 	}
-	if err := g.command("git", "checkout", "-b", branch).Run(); err != nil {
-		return fmt.Errorf("checkout to '%s' failed: %s", branch, err)
+	if err := g.command(ctx, "git", "checkout", "-b", branch).Run(); err != nil {
+		return timeoutError(ctx, "git checkout", fmt.Errorf("checkout to '%s' failed: %s", branch, err))
 	}
-	if err := g.command("git", "config", "user.name", "concourse-ci").Run(); err != nil {
-		return fmt.Errorf("failed to configure git user: %s", err)
+	if err := g.command(ctx, "git", "config", "user.name", "concourse-ci").Run(); err != nil {
+		return timeoutError(ctx, "git config", fmt.Errorf("failed to configure git user: %s", err))
 	}
-	if err := g.command("git", "config", "user.email", "concourse@local").Run(); err != nil {
-		return fmt.Errorf("failed to configure git email: %s", err)
+	if err := g.command(ctx, "git", "config", "user.email", "concourse@local").Run(); err != nil {
+		return timeoutError(ctx, "git config", fmt.Errorf("failed to configure git email: %s", err))
 	}
-	if err := g.command("git", "config", "url.https://x-oauth-basic@github.com/.insteadOf", "git@github.com:").Run(); err != nil {
-		return fmt.Errorf("failed to configure github url: %s", err)
+	if g.SSHCommand == "" {
+		if err := g.command(ctx, "git", "config", "url.https://x-oauth-basic@github.com/.insteadOf", "git@github.com:").Run(); err != nil {
+			return timeoutError(ctx, "git config", fmt.Errorf("failed to configure github url: %s", err))
+		}
 	}
-	if err := g.command("git", "config", "url.https://.insteadOf", "git://").Run(); err != nil {
-		return fmt.Errorf("failed to configure github url: %s", err)
+	if err := g.command(ctx, "git", "config", "url.https://.insteadOf", "git://").Run(); err != nil {
+		return timeoutError(ctx, "git config", fmt.Errorf("failed to configure github url: %s", err))
+	}
+	if g.CABundle != "" {
+		// Written inside .git so that the configuration stays valid for subsequent tasks. http.sslCAInfo
+		// replaces the trust store of git, so the system certificates are included (as for the API clients).
+		caPath := filepath.Join(g.Directory, ".git", "ca_bundle.pem")
+		if err := ioutil.WriteFile(caPath, append(systemCABundle(), g.CABundle...), 0644); err != nil {
+			return fmt.Errorf("failed to write ca bundle to file: %s", err)
+		}
+		if err := g.command(ctx, "git", "config", "http.sslCAInfo", caPath).Run(); err != nil {
+			return timeoutError(ctx, "git config", fmt.Errorf("failed to configure ca bundle: %s", err))
+		}
+	}
+	if g.ProxyURL != "" {
+		if err := g.command(ctx, "git", "config", "http.proxy", g.ProxyURL).Run(); err != nil {
+			return timeoutError(ctx, "git config", fmt.Errorf("failed to configure proxy: %s", err))
+		}
+	}
+	return nil
+}
+
+// SparseCheckout restricts the working tree to the given directories (cone mode).
+func (g *GitClient) SparseCheckout(ctx context.Context, patterns []string) error {
+	if err := g.command(ctx, "git", "sparse-checkout", "init", "--cone").Run(); err != nil {
+		return timeoutError(ctx, "git sparse-checkout", fmt.Errorf("sparse-checkout init failed: %s", err))
+	}
+	args := append([]string{"sparse-checkout", "set"}, patterns...)
+	if err := g.command(ctx, "git", args...).Run(); err != nil {
+		return timeoutError(ctx, "git sparse-checkout", fmt.Errorf("sparse-checkout set failed: %s", err))
 	}
 	return nil
 }
 
 // Pull ...
-func (g *GitClient) Pull(uri, branch string, depth int, submodules bool) error {
+func (g *GitClient) Pull(ctx context.Context, uri, branch string, depth int, submodules, partialClone bool) error {
 	endpoint, err := g.Endpoint(uri)
 	if err != nil {
 		return err
 	}
+	remote := endpoint + ".git"
+	if partialClone {
+		if remote, err = g.promisorRemote(ctx, uri); err != nil {
+			return err
+		}
+	}
 
-	args := []string{"pull", endpoint + ".git", branch}
+	args := []string{"pull", remote, branch}
 	if depth > 0 {
 		args = append(args, "--depth", strconv.Itoa(depth))
 	}
 	if submodules {
 		args = append(args, "--recurse-submodules")
 	}
-	cmd := g.command("git", args...)
+	cmd := g.command(ctx, "git", args...)
 
-	// Discard output to have zero chance of logging the access token.
-	cmd.Stdout = ioutil.Discard
-	cmd.Stderr = ioutil.Discard
+	// Discard output to have zero chance of logging the access token. The output is not
+	// redirected to ioutil.Discard, which would leave child processes holding a pipe.
+	cmd.Stdout = nil
+	cmd.Stderr = nil
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("pull failed: %s", cmd)
+	if err := run(ctx, cmd); err != nil {
+		return timeoutError(ctx, "git pull", fmt.Errorf("pull failed: %s", cmd))
 	}
 	if submodules {
-		submodulesGet := g.command("git", "submodule", "update", "--init", "--recursive")
-		if err := submodulesGet.Run(); err != nil {
-			return fmt.Errorf("submodule update failed: %s", err)
+		submodulesGet := g.command(ctx, "git", "submodule", "update", "--init", "--recursive")
+		if err := run(ctx, submodulesGet); err != nil {
+			return timeoutError(ctx, "git submodule update", fmt.Errorf("submodule update failed: %s", err))
 		}
 	}
 	return nil
 }
 
 // RevParse retrieves the SHA of the given branch.
-func (g *GitClient) RevParse(branch string) (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--verify", branch)
+func (g *GitClient) RevParse(ctx context.Context, branch string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", branch)
 	cmd.Dir = g.Directory
 	sha, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("rev-parse '%s' failed: %s: %s", branch, err, string(sha))
+		return "", timeoutError(ctx, "git rev-parse", fmt.Errorf("rev-parse '%s' failed: %s: %s", branch, err, string(sha)))
 	}
 	return strings.TrimSpace(string(sha)), nil
 }
 
 // Fetch ...
-func (g *GitClient) Fetch(uri string, prNumber int, depth int, submodules bool) error {
-	endpoint, err := g.Endpoint(uri)
+func (g *GitClient) Fetch(ctx context.Context, uri string, prNumber int, depth int, submodules, partialClone bool) error {
+	return g.fetch(ctx, uri, fmt.Sprintf("pull/%s/head", strconv.Itoa(prNumber)), depth, submodules, partialClone)
+}
+
+// FetchMerge fetches the merge commit created by Github for the pull request.
+func (g *GitClient) FetchMerge(ctx context.Context, uri string, prNumber int, depth int, submodules, partialClone bool) error {
+	return g.fetch(ctx, uri, fmt.Sprintf("pull/%s/merge", strconv.Itoa(prNumber)), depth, submodules, partialClone)
+}
+
+// FetchCommit fetches a commit by its SHA, e.g. when it is no longer the head of the pull request.
+func (g *GitClient) FetchCommit(ctx context.Context, uri, sha string, depth int, submodules, partialClone bool) error {
+	return g.fetch(ctx, uri, sha, depth, submodules, partialClone)
+}
+
+func (g *GitClient) fetch(ctx context.Context, uri, ref string, depth int, submodules, partialClone bool) error {
+	remote, err := g.Endpoint(uri)
 	if err != nil {
 		return err
 	}
+	if partialClone {
+		if remote, err = g.promisorRemote(ctx, uri); err != nil {
+			return err
+		}
+	}
 
-	args := []string{"fetch", endpoint, fmt.Sprintf("pull/%s/head", strconv.Itoa(prNumber))}
+	args := []string{"fetch", remote, ref}
 	if depth > 0 {
 		args = append(args, "--depth", strconv.Itoa(depth))
 	}
 	if submodules {
 		args = append(args, "--recurse-submodules")
 	}
-	cmd := g.command("git", args...)
+	cmd := g.command(ctx, "git", args...)
 
-	// Discard output to have zero chance of logging the access token.
-	cmd.Stdout = ioutil.Discard
-	cmd.Stderr = ioutil.Discard
+	// Discard output to have zero chance of logging the access token. The output is not
+	// redirected to ioutil.Discard, which would leave child processes holding a pipe.
+	cmd.Stdout = nil
+	cmd.Stderr = nil
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("fetch failed: %s", err)
+	if err := run(ctx, cmd); err != nil {
+		return timeoutError(ctx, "git fetch", fmt.Errorf("fetch failed: %s", err))
 	}
 	return nil
 }
 
+// Parents returns the SHAs of the parents of a commit. The commit object is read directly
+// so that the parents are also available in shallow clones.
+func (g *GitClient) Parents(ctx context.Context, rev string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "commit", rev)
+	cmd.Dir = g.Directory
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, timeoutError(ctx, "git cat-file", fmt.Errorf("cat-file '%s' failed: %s", rev, err))
+	}
+
+	var parents []string
+	for _, line := range strings.Split(string(out), "\n") {
+		// The headers end at the first blank line.
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "parent ") {
+			parents = append(parents, strings.TrimPrefix(line, "parent "))
+		}
+	}
+	return parents, nil
+}
+
+// Reset the current branch to the given commit.
+func (g *GitClient) Reset(ctx context.Context, sha string, submodules bool) error {
+	if err := g.command(ctx, "git", "reset", "--hard", sha).Run(); err != nil {
+		return timeoutError(ctx, "git reset", fmt.Errorf("reset failed: %s", err))
+	}
+
+	if submodules {
+		if err := g.command(ctx, "git", "submodule", "update", "--init", "--recursive", "--checkout").Run(); err != nil {
+			return timeoutError(ctx, "git submodule update", fmt.Errorf("submodule update failed: %s", err))
+		}
+	}
+
+	return nil
+}
+
 // CheckOut
-func (g *GitClient) Checkout(branch, sha string, submodules bool) error {
-	if err := g.command("git", "checkout", "-b", branch, sha).Run(); err != nil {
-		return fmt.Errorf("checkout failed: %s", err)
+func (g *GitClient) Checkout(ctx context.Context, branch, sha string, submodules bool) error {
+	if err := g.command(ctx, "git", "checkout", "-b", branch, sha).Run(); err != nil {
+		return timeoutError(ctx, "git checkout", fmt.Errorf("checkout failed: %s", err))
 	}
 
 	if submodules {
-		if err := g.command("git", "submodule", "update", "--init", "--recursive", "--checkout").Run(); err != nil {
-			return fmt.Errorf("submodule update failed: %s", err)
+		if err := g.command(ctx, "git", "submodule", "update", "--init", "--recursive", "--checkout").Run(); err != nil {
+			return timeoutError(ctx, "git submodule update", fmt.Errorf("submodule update failed: %s", err))
 		}
 	}
 
@@ -165,14 +299,14 @@ func (g *GitClient) Checkout(branch, sha string, submodules bool) error {
 }
 
 // Merge ...
-func (g *GitClient) Merge(sha string, submodules bool) error {
-	if err := g.command("git", "merge", sha, "--no-stat").Run(); err != nil {
-		return fmt.Errorf("merge failed: %s", err)
+func (g *GitClient) Merge(ctx context.Context, sha string, submodules bool) error {
+	if err := g.command(ctx, "git", "merge", sha, "--no-stat").Run(); err != nil {
+		return timeoutError(ctx, "git merge", fmt.Errorf("merge failed: %s", err))
 	}
 
 	if submodules {
-		if err := g.command("git", "submodule", "update", "--init", "--recursive", "--merge").Run(); err != nil {
-			return fmt.Errorf("submodule update failed: %s", err)
+		if err := g.command(ctx, "git", "submodule", "update", "--init", "--recursive", "--merge").Run(); err != nil {
+			return timeoutError(ctx, "git submodule update", fmt.Errorf("submodule update failed: %s", err))
 		}
 	}
 
@@ -180,22 +314,113 @@ func (g *GitClient) Merge(sha string, submodules bool) error {
 }
 
 // Rebase ...
-func (g *GitClient) Rebase(baseRef string, headSha string, submodules bool) error {
-	if err := g.command("git", "rebase", baseRef, headSha).Run(); err != nil {
-		return fmt.Errorf("rebase failed: %s", err)
+func (g *GitClient) Rebase(ctx context.Context, baseRef string, headSha string, submodules bool) error {
+	if err := g.command(ctx, "git", "rebase", baseRef, headSha).Run(); err != nil {
+		return timeoutError(ctx, "git rebase", fmt.Errorf("rebase failed: %s", err))
 	}
 
 	if submodules {
-		if err := g.command("git", "submodule", "update", "--init", "--recursive", "--rebase").Run(); err != nil {
-			return fmt.Errorf("submodule update failed: %s", err)
+		if err := g.command(ctx, "git", "submodule", "update", "--init", "--recursive", "--rebase").Run(); err != nil {
+			return timeoutError(ctx, "git submodule update", fmt.Errorf("submodule update failed: %s", err))
+		}
+	}
+
+	return nil
+}
+
+// Squash the given commit into a single commit on top of the current branch.
+func (g *GitClient) Squash(ctx context.Context, sha, message string, submodules bool) error {
+	if err := g.command(ctx, "git", "merge", "--squash", sha, "--no-stat").Run(); err != nil {
+		return timeoutError(ctx, "git merge", fmt.Errorf("squash failed: %s", err))
+	}
+	if err := g.command(ctx, "git", "commit", "--allow-empty", "--message", message).Run(); err != nil {
+		return timeoutError(ctx, "git commit", fmt.Errorf("squash commit failed: %s", err))
+	}
+
+	if submodules {
+		if err := g.command(ctx, "git", "submodule", "update", "--init", "--recursive", "--merge").Run(); err != nil {
+			return timeoutError(ctx, "git submodule update", fmt.Errorf("submodule update failed: %s", err))
+		}
+	}
+
+	return nil
+}
+
+// Conflicts lists the files with unresolved merge conflicts.
+func (g *GitClient) Conflicts(ctx context.Context) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", "--diff-filter=U")
+	cmd.Dir = g.Directory
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, timeoutError(ctx, "git diff", fmt.Errorf("failed to list conflicts: %s", err))
+	}
+	var files []string
+	for _, f := range strings.Split(string(out), "\n") {
+		if f != "" {
+			files = append(files, f)
 		}
 	}
+	return files, nil
+}
+
+// LFSPull fetches and checks out the LFS objects for the current HEAD.
+func (g *GitClient) LFSPull(ctx context.Context, uri string, include, exclude []string) error {
+	endpoint, err := g.Endpoint(uri)
+	if err != nil {
+		return err
+	}
+
+	// The LFS server is resolved from the remote when using SSH.
+	lfsURL := endpoint + ".git/info/lfs"
+	if g.SSHCommand != "" {
+		lfsURL = endpoint + ".git"
+	}
+
+	if err := run(ctx, g.command(ctx, "git", "lfs", "install", "--local")); err != nil {
+		return timeoutError(ctx, "git lfs install", fmt.Errorf("lfs install failed: %s", err))
+	}
+
+	args := []string{"-c", "lfs.url=" + lfsURL, "lfs", "pull"}
+	if len(include) > 0 {
+		args = append(args, "--include", strings.Join(include, ","))
+	}
+	if len(exclude) > 0 {
+		args = append(args, "--exclude", strings.Join(exclude, ","))
+	}
+	cmd := g.command(ctx, "git", args...)
+
+	// Discard output to have zero chance of logging the access token. The output is not
+	// redirected to ioutil.Discard, which would leave child processes holding a pipe.
+	cmd.Stdout = nil
+	cmd.Stderr = nil
 
+	if err := run(ctx, cmd); err != nil {
+		return timeoutError(ctx, "git lfs pull", fmt.Errorf("lfs pull failed: %s", err))
+	}
 	return nil
 }
 
+// run the command in its own process group, and kill the whole group when the context is done. Otherwise
+// the helpers started by git (e.g. git-remote-https or git-lfs) keep running after a timeout.
+func run(ctx context.Context, cmd *exec.Cmd) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		case <-done:
+		}
+	}()
+	return cmd.Wait()
+}
+
 // GitCryptUnlock unlocks the repository using git-crypt
-func (g *GitClient) GitCryptUnlock(base64key string) error {
+func (g *GitClient) GitCryptUnlock(ctx context.Context, base64key string) error {
 	keyDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		return fmt.Errorf("failed to create temporary directory")
@@ -209,18 +434,134 @@ func (g *GitClient) GitCryptUnlock(base64key string) error {
 	if err := ioutil.WriteFile(keyPath, decodedKey, 600); err != nil {
 		return fmt.Errorf("failed to write git-crypt key to file: %s", err)
 	}
-	if err := g.command("git-crypt", "unlock", keyPath).Run(); err != nil {
-		return fmt.Errorf("git-crypt unlock failed: %s", err)
+	if err := g.command(ctx, "git-crypt", "unlock", keyPath).Run(); err != nil {
+		return timeoutError(ctx, "git-crypt unlock", fmt.Errorf("git-crypt unlock failed: %s", err))
+	}
+	return nil
+}
+
+// VerifyCommit verifies the GPG signature of a commit against the given (ASCII armored) public keys.
+func (g *GitClient) VerifyCommit(ctx context.Context, keyring, sha string) error {
+	gnupgHome, err := ioutil.TempDir("", "")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory")
+	}
+	defer os.RemoveAll(gnupgHome)
+
+	cmd := g.command(ctx, "gpg", "--batch", "--import")
+	cmd.Env = append(cmd.Env, "GNUPGHOME="+gnupgHome)
+	cmd.Stdin = strings.NewReader(keyring)
+	if err := cmd.Run(); err != nil {
+		return timeoutError(ctx, "gpg import", fmt.Errorf("failed to import signature keyring: %s", err))
+	}
+	cmd = g.command(ctx, "git", "verify-commit", sha)
+	cmd.Env = append(cmd.Env, "GNUPGHOME="+gnupgHome)
+	if err := cmd.Run(); err != nil {
+		return timeoutError(ctx, "git verify-commit", fmt.Errorf("verify-commit failed: %s", err))
 	}
 	return nil
 }
 
 // Endpoint takes an uri and produces an endpoint with the login information baked in.
+// When using SSH the endpoint is rewritten to use the SSH transport instead.
 func (g *GitClient) Endpoint(uri string) (string, error) {
 	endpoint, err := url.Parse(uri)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse commit url: %s", err)
 	}
+	if g.SSHCommand != "" {
+		endpoint.Scheme = "ssh"
+		endpoint.Host = endpoint.Hostname()
+		endpoint.User = url.User("git")
+		return endpoint.String(), nil
+	}
 	endpoint.User = url.UserPassword("x-oauth-basic", g.AccessToken)
 	return endpoint.String(), nil
 }
+
+// promisorRemote configures a remote for blob-less partial clones (which are not supported
+// when fetching from an URL) and returns its name. The access token is not stored in the
+// configuration, it is provided by askpass instead.
+func (g *GitClient) promisorRemote(ctx context.Context, uri string) (string, error) {
+	endpoint, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse commit url: %s", err)
+	}
+	endpoint.User = url.User("x-oauth-basic")
+	remoteURL := endpoint.String()
+
+	if g.SSHCommand != "" {
+		if remoteURL, err = g.Endpoint(uri); err != nil {
+			return "", err
+		}
+	}
+
+	config := [][]string{
+		{"remote.origin.url", remoteURL},
+		{"remote.origin.promisor", "true"},
+		{"remote.origin.partialclonefilter", "blob:none"},
+	}
+	for _, c := range config {
+		if err := g.command(ctx, "git", "config", c[0], c[1]).Run(); err != nil {
+			return "", timeoutError(ctx, "git config", fmt.Errorf("failed to configure partial clone: %s", err))
+		}
+	}
+	return "origin", nil
+}
+
+// systemCABundles are the locations of the system certificates on common Linux distributions.
+var systemCABundles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/pki/tls/cacert.pem",
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// systemCABundle returns the PEM encoded system certificates (terminated by a newline), or nil if none
+// are found. SSL_CERT_FILE takes precedence over the default locations.
+func systemCABundle() []byte {
+	paths := systemCABundles
+	if f := os.Getenv("SSL_CERT_FILE"); f != "" {
+		paths = []string{f}
+	}
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil || len(b) == 0 {
+			continue
+		}
+		if b[len(b)-1] != '\n' {
+			b = append(b, '\n')
+		}
+		return b
+	}
+	return nil
+}
+
+// newSSHCommand writes the private key (and known hosts) to a temporary directory and
+// returns the command git should use for SSH connections, along with the directory.
+func newSSHCommand(source *Source) (string, string, error) {
+	dir, err := ioutil.TempDir("", "github-pr-resource")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temporary directory")
+	}
+	keyPath := filepath.Join(dir, "private_key")
+	if err := ioutil.WriteFile(keyPath, []byte(strings.TrimSpace(source.PrivateKey)+"\n"), 0600); err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("failed to write private key to file: %s", err)
+	}
+	args := []string{"ssh", "-i", keyPath, "-o", "IdentitiesOnly=yes"}
+
+	if source.SkipHostKeyChecking {
+		args = append(args, "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null")
+	} else {
+		knownHostsPath := filepath.Join(dir, "known_hosts")
+		if err := ioutil.WriteFile(knownHostsPath, []byte(source.KnownHosts), 0644); err != nil {
+			os.RemoveAll(dir)
+			return "", "", fmt.Errorf("failed to write known hosts to file: %s", err)
+		}
+		args = append(args, "-o", "StrictHostKeyChecking=yes", "-o", "UserKnownHostsFile="+knownHostsPath)
+	}
+	return strings.Join(args, " "), dir, nil
+}
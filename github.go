@@ -1,8 +1,10 @@
 package resource
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -20,13 +22,19 @@ import (
 // Github for testing purposes.
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -o fakes/fake_github.go . Github
 type Github interface {
-	ListOpenPullRequests() ([]*PullRequest, error)
-	ListModifiedFiles(int) ([]string, error)
-	PostComment(string, string) error
-	GetPullRequest(string, string) (*PullRequest, error)
-	GetChangedFiles(string, string) ([]ChangedFileObject, error)
-	UpdateCommitStatus(string, string, string, string, string, string) error
-	DeletePreviousComments(string) error
+	ListOpenPullRequests(context.Context) ([]*PullRequest, error)
+	ListModifiedFiles(context.Context, int) ([]string, error)
+	PostComment(context.Context, string, string) error
+	GetPullRequest(context.Context, string, string) (*PullRequest, error)
+	GetChangedFiles(context.Context, string, string) ([]ChangedFileObject, error)
+	GetDiff(context.Context, string, string) (string, error)
+	GetPatch(context.Context, string) (string, error)
+	UpdateCommitStatus(context.Context, string, string, string, string, string, string) error
+	GetCommitStatuses(context.Context, string, string) (map[string]string, error)
+	CreateDeployment(context.Context, string, string, string, bool) (int64, error)
+	GetLatestDeployment(context.Context, string, string) (int64, error)
+	CreateDeploymentStatus(context.Context, int64, string, string, string) error
+	DeletePreviousComments(context.Context, string) error
 }
 
 // GithubClient for handling requests to the Github V3 and V4 APIs.
@@ -44,18 +52,13 @@ func NewGithubClient(s *Source) (*GithubClient, error) {
 		return nil, err
 	}
 
-	// Skip SSL verification for self-signed certificates
+	// Use a custom HTTP client for proxies, self-signed certificates etc.
 	// source: https://github.com/google/go-github/pull/598#issuecomment-333039238
-	var ctx context.Context
-	if s.SkipSSLVerification {
-		insecureClient := &http.Client{Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-		}
-		ctx = context.WithValue(context.TODO(), oauth2.HTTPClient, insecureClient)
-	} else {
-		ctx = context.TODO()
+	httpClient, err := newHTTPClient(s)
+	if err != nil {
+		return nil, err
 	}
+	ctx := context.WithValue(context.TODO(), oauth2.HTTPClient, httpClient)
 
 	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: s.AccessToken},
@@ -98,13 +101,18 @@ func NewGithubClient(s *Source) (*GithubClient, error) {
 }
 
 // ListOpenPullRequests gets the last commit on all open pull requests.
-func (m *GithubClient) ListOpenPullRequests() ([]*PullRequest, error) {
+func (m *GithubClient) ListOpenPullRequests(ctx context.Context) ([]*PullRequest, error) {
 	var query struct {
 		Repository struct {
 			PullRequests struct {
 				Edges []struct {
 					Node struct {
 						PullRequestObject
+						BaseRef struct {
+							Target struct {
+								Commit CommitObject `graphql:"... on Commit"`
+							}
+						}
 						Reviews struct {
 							TotalCount int
 						} `graphql:"reviews(states: $prReviewStates)"`
@@ -145,8 +153,8 @@ func (m *GithubClient) ListOpenPullRequests() ([]*PullRequest, error) {
 
 	var response []*PullRequest
 	for {
-		if err := m.V4.Query(context.TODO(), &query, vars); err != nil {
-			return nil, err
+		if err := m.V4.Query(ctx, &query, vars); err != nil {
+			return nil, timeoutError(ctx, "list open pull requests", err)
 		}
 		for _, p := range query.Repository.PullRequests.Edges {
 			labels := make([]LabelObject, len(p.Node.Labels.Edges))
@@ -158,6 +166,7 @@ func (m *GithubClient) ListOpenPullRequests() ([]*PullRequest, error) {
 				response = append(response, &PullRequest{
 					PullRequestObject:   p.Node.PullRequestObject,
 					Tip:                 c.Node.Commit,
+					BaseTip:             p.Node.BaseRef.Target.Commit,
 					ApprovedReviewCount: p.Node.Reviews.TotalCount,
 					Labels:              labels,
 				})
@@ -172,7 +181,7 @@ func (m *GithubClient) ListOpenPullRequests() ([]*PullRequest, error) {
 }
 
 // ListModifiedFiles in a pull request (not supported by V4 API).
-func (m *GithubClient) ListModifiedFiles(prNumber int) ([]string, error) {
+func (m *GithubClient) ListModifiedFiles(ctx context.Context, prNumber int) ([]string, error) {
 	var files []string
 
 	opt := &github.ListOptions{
@@ -180,14 +189,14 @@ func (m *GithubClient) ListModifiedFiles(prNumber int) ([]string, error) {
 	}
 	for {
 		result, response, err := m.V3.PullRequests.ListFiles(
-			context.TODO(),
+			ctx,
 			m.Owner,
 			m.Repository,
 			prNumber,
 			opt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, timeoutError(ctx, "list modified files", err)
 		}
 		for _, f := range result {
 			files = append(files, *f.Filename)
@@ -201,14 +210,14 @@ func (m *GithubClient) ListModifiedFiles(prNumber int) ([]string, error) {
 }
 
 // PostComment to a pull request or issue.
-func (m *GithubClient) PostComment(prNumber, comment string) error {
+func (m *GithubClient) PostComment(ctx context.Context, prNumber, comment string) error {
 	pr, err := strconv.Atoi(prNumber)
 	if err != nil {
 		return fmt.Errorf("failed to convert pull request number to int: %s", err)
 	}
 
 	_, _, err = m.V3.Issues.CreateComment(
-		context.TODO(),
+		ctx,
 		m.Owner,
 		m.Repository,
 		pr,
@@ -216,11 +225,11 @@ func (m *GithubClient) PostComment(prNumber, comment string) error {
 			Body: github.String(comment),
 		},
 	)
-	return err
+	return timeoutError(ctx, "post comment", err)
 }
 
-// GetChangedFiles ...
-func (m *GithubClient) GetChangedFiles(prNumber string, commitRef string) ([]ChangedFileObject, error) {
+// GetChangedFiles in a pull request, including the type of change and line counts (not supported by V4 API).
+func (m *GithubClient) GetChangedFiles(ctx context.Context, prNumber string, commitRef string) ([]ChangedFileObject, error) {
 	pr, err := strconv.Atoi(prNumber)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert pull request number to int: %s", err)
@@ -228,103 +237,252 @@ func (m *GithubClient) GetChangedFiles(prNumber string, commitRef string) ([]Cha
 
 	var cfo []ChangedFileObject
 
-	var filequery struct {
+	opt := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		result, response, err := m.V3.PullRequests.ListFiles(
+			ctx,
+			m.Owner,
+			m.Repository,
+			pr,
+			opt,
+		)
+		if err != nil {
+			return nil, timeoutError(ctx, "get changed files", err)
+		}
+		for _, f := range result {
+			cfo = append(cfo, ChangedFileObject{
+				Path:         f.GetFilename(),
+				ChangeType:   f.GetStatus(),
+				PreviousPath: f.GetPreviousFilename(),
+				Additions:    f.GetAdditions(),
+				Deletions:    f.GetDeletions(),
+			})
+		}
+		if response.NextPage == 0 {
+			break
+		}
+		opt.Page = response.NextPage
+	}
+	return cfo, nil
+}
+
+// GetPullRequest ...
+func (m *GithubClient) GetPullRequest(ctx context.Context, prNumber, commitRef string) (*PullRequest, error) {
+	pr, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pull request number to int: %s", err)
+	}
+
+	var query struct {
 		Repository struct {
 			PullRequest struct {
-				Files struct {
+				PullRequestObject
+				Mergeable githubv4.MergeableState
+				Commits   struct {
 					Edges []struct {
 						Node struct {
-							ChangedFileObject
+							Commit CommitObject
 						}
-					} `graphql:"edges"`
+					}
 					PageInfo struct {
-						EndCursor   githubv4.String
-						HasNextPage bool
-					} `graphql:"pageInfo"`
-				} `graphql:"files(first:$changedFilesFirst, after: $changedFilesEndCursor)"`
+						StartCursor     githubv4.String
+						HasPreviousPage bool
+					}
+				} `graphql:"commits(last:$commitsLast,before:$commitsCursor)"`
+				Labels struct {
+					Edges []struct {
+						Node struct {
+							LabelObject
+						}
+					}
+				} `graphql:"labels(first:$labelsFirst)"`
+				ReviewRequests struct {
+					Edges []struct {
+						Node struct {
+							RequestedReviewer struct {
+								User struct {
+									Login string
+								} `graphql:"... on User"`
+								Team struct {
+									Slug string
+								} `graphql:"... on Team"`
+							}
+						}
+					}
+				} `graphql:"reviewRequests(first:$reviewRequestsFirst)"`
+				Assignees struct {
+					Edges []struct {
+						Node struct {
+							Login string
+						}
+					}
+				} `graphql:"assignees(first:$assigneesFirst)"`
 			} `graphql:"pullRequest(number:$prNumber)"`
 		} `graphql:"repository(owner:$repositoryOwner,name:$repositoryName)"`
 	}
 
-	offset := ""
+	vars := map[string]interface{}{
+		"repositoryOwner":     githubv4.String(m.Owner),
+		"repositoryName":      githubv4.String(m.Repository),
+		"prNumber":            githubv4.Int(pr),
+		"commitsLast":         githubv4.Int(100),
+		"commitsCursor":       (*githubv4.String)(nil),
+		"labelsFirst":         githubv4.Int(100),
+		"reviewRequestsFirst": githubv4.Int(100),
+		"assigneesFirst":      githubv4.Int(100),
+	}
 
+	// Paginate backwards (from the latest commit) to list all commits in the pull request.
+	var commits []CommitObject
 	for {
-		vars := map[string]interface{}{
-			"repositoryOwner":       githubv4.String(m.Owner),
-			"repositoryName":        githubv4.String(m.Repository),
-			"prNumber":              githubv4.Int(pr),
-			"changedFilesFirst":     githubv4.Int(100),
-			"changedFilesEndCursor": githubv4.String(offset),
+		if err := m.V4.Query(ctx, &query, vars); err != nil {
+			return nil, timeoutError(ctx, "get pull request", err)
 		}
-
-		if err := m.V4.Query(context.TODO(), &filequery, vars); err != nil {
-			return nil, err
+		page := make([]CommitObject, 0, len(query.Repository.PullRequest.Commits.Edges))
+		for _, c := range query.Repository.PullRequest.Commits.Edges {
+			page = append(page, c.Node.Commit)
 		}
+		commits = append(page, commits...)
 
-		for _, f := range filequery.Repository.PullRequest.Files.Edges {
-			cfo = append(cfo, ChangedFileObject{Path: f.Node.Path})
+		if !query.Repository.PullRequest.Commits.PageInfo.HasPreviousPage {
+			break
 		}
+		vars["commitsCursor"] = githubv4.NewString(query.Repository.PullRequest.Commits.PageInfo.StartCursor)
+	}
 
-		if !filequery.Repository.PullRequest.Files.PageInfo.HasNextPage {
-			break
+	var labels []LabelObject
+	for _, l := range query.Repository.PullRequest.Labels.Edges {
+		labels = append(labels, l.Node.LabelObject)
+	}
+
+	// Requested reviewers are either users or teams.
+	var reviewers []string
+	for _, r := range query.Repository.PullRequest.ReviewRequests.Edges {
+		if login := r.Node.RequestedReviewer.User.Login; login != "" {
+			reviewers = append(reviewers, login)
+		} else if slug := r.Node.RequestedReviewer.Team.Slug; slug != "" {
+			reviewers = append(reviewers, slug)
 		}
+	}
 
-		offset = string(filequery.Repository.PullRequest.Files.PageInfo.EndCursor)
+	var assignees []string
+	for _, a := range query.Repository.PullRequest.Assignees.Edges {
+		assignees = append(assignees, a.Node.Login)
 	}
 
-	return cfo, nil
-}
+	pull := &PullRequest{
+		PullRequestObject:  query.Repository.PullRequest.PullRequestObject,
+		Labels:             labels,
+		RequestedReviewers: reviewers,
+		Assignees:          assignees,
+		Mergeable:          query.Repository.PullRequest.Mergeable,
+	}
 
-// GetPullRequest ...
-func (m *GithubClient) GetPullRequest(prNumber, commitRef string) (*PullRequest, error) {
-	pr, err := strconv.Atoi(prNumber)
+	for i, c := range commits {
+		if c.OID == commitRef {
+			pull.Tip = c
+			pull.Commits = commits[:i+1]
+			return pull, nil
+		}
+	}
+
+	// The commit is no longer part of the pull request (e.g. after a force push), but
+	// it might still be reachable in the repository. Only the commit itself is known,
+	// so Commits is left empty.
+	commit, err := m.getCommit(ctx, commitRef, pr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert pull request number to int: %s", err)
+		return nil, err
+	}
+	if commit == nil {
+		return nil, &SupersededError{Commit: commitRef, SupersededBy: pull.HeadRefOID, Missing: true}
+	}
+	pull.Tip = *commit
+	pull.ForcePushed = true
+	return pull, nil
+}
+
+// getCommit looks up a commit by its SHA in the repository or the head repository of the pull request (for forks),
+// and makes sure that it has been part of the pull request. The commit is nil if it does not exist.
+func (m *GithubClient) getCommit(ctx context.Context, commitRef string, prNumber int) (*CommitObject, error) {
+	type object struct {
+		Commit struct {
+			CommitObject
+			AssociatedPullRequests struct {
+				Edges []struct {
+					Node struct {
+						Number int
+					}
+				}
+			} `graphql:"associatedPullRequests(first:$associatedPullRequestsFirst)"`
+		} `graphql:"... on Commit"`
 	}
 
 	var query struct {
 		Repository struct {
+			Object      object `graphql:"object(oid:$commitOid)"`
 			PullRequest struct {
-				PullRequestObject
-				Commits struct {
-					Edges []struct {
-						Node struct {
-							Commit CommitObject
-						}
-					}
-				} `graphql:"commits(last:$commitsLast)"`
+				HeadRepository struct {
+					Object object `graphql:"object(oid:$commitOid)"`
+				}
 			} `graphql:"pullRequest(number:$prNumber)"`
 		} `graphql:"repository(owner:$repositoryOwner,name:$repositoryName)"`
 	}
 
 	vars := map[string]interface{}{
-		"repositoryOwner": githubv4.String(m.Owner),
-		"repositoryName":  githubv4.String(m.Repository),
-		"prNumber":        githubv4.Int(pr),
-		"commitsLast":     githubv4.Int(100),
+		"repositoryOwner":             githubv4.String(m.Owner),
+		"repositoryName":              githubv4.String(m.Repository),
+		"prNumber":                    githubv4.Int(prNumber),
+		"commitOid":                   githubv4.GitObjectID(commitRef),
+		"associatedPullRequestsFirst": githubv4.Int(100),
 	}
 
-	// TODO: Pagination - in case someone pushes > 100 commits before the build has time to start :p
-	if err := m.V4.Query(context.TODO(), &query, vars); err != nil {
-		return nil, err
+	if err := m.V4.Query(ctx, &query, vars); err != nil {
+		return nil, timeoutError(ctx, "get commit", err)
 	}
 
-	for _, c := range query.Repository.PullRequest.Commits.Edges {
-		if c.Node.Commit.OID == commitRef {
-			// Return as soon as we find the correct ref.
-			return &PullRequest{
-				PullRequestObject: query.Repository.PullRequest.PullRequestObject,
-				Tip:               c.Node.Commit,
-			}, nil
+	commit := query.Repository.Object.Commit
+	if commit.OID != commitRef {
+		commit = query.Repository.PullRequest.HeadRepository.Object.Commit
+	}
+	if commit.OID != commitRef {
+		return nil, nil
+	}
+	for _, p := range commit.AssociatedPullRequests.Edges {
+		if p.Node.Number == prNumber {
+			return &commit.CommitObject, nil
 		}
 	}
+	return nil, fmt.Errorf("commit with ref '%s' does not belong to pull request %d", commitRef, prNumber)
+}
+
+// GetDiff returns the unified diff of head against the merge base of base and head (not supported by V4 API).
+func (m *GithubClient) GetDiff(ctx context.Context, base, head string) (string, error) {
+	req, err := m.V3.NewRequest("GET", fmt.Sprintf("repos/%s/%s/compare/%s...%s", m.Owner, m.Repository, base, head), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %s", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3.diff")
 
-	// Return an error if the commit was not found
-	return nil, fmt.Errorf("commit with ref '%s' does not exist", commitRef)
+	var diff bytes.Buffer
+	if _, err := m.V3.Do(ctx, req, &diff); err != nil {
+		return "", timeoutError(ctx, "get diff", err)
+	}
+	return diff.String(), nil
+}
+
+// GetPatch returns the commit formatted as an email patch (not supported by V4 API).
+func (m *GithubClient) GetPatch(ctx context.Context, sha string) (string, error) {
+	patch, _, err := m.V3.Repositories.GetCommitRaw(ctx, m.Owner, m.Repository, sha, github.RawOptions{Type: github.Patch})
+	if err != nil {
+		return "", timeoutError(ctx, "get patch", err)
+	}
+	return patch, nil
 }
 
 // UpdateCommitStatus for a given commit (not supported by V4 API).
-func (m *GithubClient) UpdateCommitStatus(commitRef, baseContext, statusContext, status, targetURL, description string) error {
+func (m *GithubClient) UpdateCommitStatus(ctx context.Context, commitRef, baseContext, statusContext, status, targetURL, description string) error {
 	if baseContext == "" {
 		baseContext = "concourse-ci"
 	}
@@ -342,7 +500,7 @@ func (m *GithubClient) UpdateCommitStatus(commitRef, baseContext, statusContext,
 	}
 
 	_, _, err := m.V3.Repositories.CreateStatus(
-		context.TODO(),
+		ctx,
 		m.Owner,
 		m.Repository,
 		commitRef,
@@ -353,10 +511,93 @@ func (m *GithubClient) UpdateCommitStatus(commitRef, baseContext, statusContext,
 			Context:     github.String(path.Join(baseContext, statusContext)),
 		},
 	)
-	return err
+	return timeoutError(ctx, "update commit status", err)
+}
+
+// GetCommitStatuses returns the latest state of each status context under the base context for a given commit (not supported by V4 API).
+func (m *GithubClient) GetCommitStatuses(ctx context.Context, commitRef, baseContext string) (map[string]string, error) {
+	if baseContext == "" {
+		baseContext = "concourse-ci"
+	}
+
+	statuses := make(map[string]string)
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		combined, resp, err := m.V3.Repositories.GetCombinedStatus(ctx, m.Owner, m.Repository, commitRef, opt)
+		if err != nil {
+			return nil, timeoutError(ctx, "get commit statuses", err)
+		}
+		for _, s := range combined.Statuses {
+			if c := s.GetContext(); strings.HasPrefix(c, baseContext+"/") {
+				statuses[strings.TrimPrefix(c, baseContext+"/")] = s.GetState()
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return statuses, nil
 }
 
-func (m *GithubClient) DeletePreviousComments(prNumber string) error {
+// CreateDeployment for a given commit and returns its ID (not supported by V4 API).
+func (m *GithubClient) CreateDeployment(ctx context.Context, commitRef, environment, payload string, transient bool) (int64, error) {
+	request := &github.DeploymentRequest{
+		Ref:         github.String(commitRef),
+		Environment: github.String(environment),
+		// Do not merge the base branch or require statuses to pass, since the build deploying
+		// the commit usually has pending statuses of its own.
+		AutoMerge:            github.Bool(false),
+		RequiredContexts:     &[]string{},
+		TransientEnvironment: github.Bool(transient),
+	}
+	if payload != "" {
+		request.Payload = github.String(payload)
+	}
+
+	deployment, _, err := m.V3.Repositories.CreateDeployment(ctx, m.Owner, m.Repository, request)
+	if err != nil {
+		return 0, timeoutError(ctx, "create deployment", err)
+	}
+	return deployment.GetID(), nil
+}
+
+// GetLatestDeployment returns the ID of the latest deployment of a given commit to an environment (not supported by V4 API).
+func (m *GithubClient) GetLatestDeployment(ctx context.Context, commitRef, environment string) (int64, error) {
+	deployments, _, err := m.V3.Repositories.ListDeployments(ctx, m.Owner, m.Repository, &github.DeploymentsListOptions{
+		SHA:         commitRef,
+		Environment: environment,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return 0, timeoutError(ctx, "list deployments", err)
+	}
+	if len(deployments) == 0 {
+		return 0, fmt.Errorf("no deployment of commit %s to %s", commitRef, environment)
+	}
+	return deployments[0].GetID(), nil
+}
+
+// CreateDeploymentStatus for a given deployment (not supported by V4 API).
+func (m *GithubClient) CreateDeploymentStatus(ctx context.Context, deploymentID int64, state, environmentURL, logURL string) error {
+	if logURL == "" {
+		logURL = strings.Join([]string{os.Getenv("ATC_EXTERNAL_URL"), "builds", os.Getenv("BUILD_ID")}, "/")
+	}
+
+	request := &github.DeploymentStatusRequest{
+		State:       github.String(strings.ToLower(state)),
+		LogURL:      github.String(logURL),
+		Description: github.String(fmt.Sprintf("Concourse CI deployment %s", state)),
+	}
+	if environmentURL != "" {
+		request.EnvironmentURL = github.String(environmentURL)
+	}
+
+	_, _, err := m.V3.Repositories.CreateDeploymentStatus(ctx, m.Owner, m.Repository, deploymentID, request)
+	return timeoutError(ctx, "create deployment status", err)
+}
+
+func (m *GithubClient) DeletePreviousComments(ctx context.Context, prNumber string) error {
 	pr, err := strconv.Atoi(prNumber)
 	if err != nil {
 		return fmt.Errorf("failed to convert pull request number to int: %s", err)
@@ -390,15 +631,15 @@ func (m *GithubClient) DeletePreviousComments(prNumber string) error {
 		"commentsLast":    githubv4.Int(100),
 	}
 
-	if err := m.V4.Query(context.TODO(), &getComments, vars); err != nil {
-		return err
+	if err := m.V4.Query(ctx, &getComments, vars); err != nil {
+		return timeoutError(ctx, "list previous comments", err)
 	}
 
 	for _, e := range getComments.Repository.PullRequest.Comments.Edges {
 		if e.Node.Author.Login == getComments.Viewer.Login {
-			_, err := m.V3.Issues.DeleteComment(context.TODO(), m.Owner, m.Repository, e.Node.DatabaseId)
+			_, err := m.V3.Issues.DeleteComment(ctx, m.Owner, m.Repository, e.Node.DatabaseId)
 			if err != nil {
-				return err
+				return timeoutError(ctx, "delete previous comment", err)
 			}
 		}
 	}
@@ -406,6 +647,34 @@ func (m *GithubClient) DeletePreviousComments(prNumber string) error {
 	return nil
 }
 
+// newHTTPClient creates a HTTP client which respects the proxy and TLS
+// settings in the source configuration.
+func newHTTPClient(s *Source) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: s.SkipSSLVerification}
+
+	if s.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(s.CABundle)) {
+			return nil, errors.New("failed to parse certificates in ca_bundle")
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if s.ProxyURL != "" {
+		proxy, err := url.Parse(s.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy url: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
 func parseRepository(s string) (string, string, error) {
 	parts := strings.Split(s, "/")
 	if len(parts) != 2 {
@@ -413,3 +682,27 @@ func parseRepository(s string) (string, string, error) {
 	}
 	return parts[0], parts[1], nil
 }
+
+// SupersededError is returned when the requested commit has been force-pushed away from the pull request.
+type SupersededError struct {
+	Commit       string
+	SupersededBy string
+	// Missing is set if the commit no longer exists on Github (and cannot be fetched).
+	Missing bool
+}
+
+func (e *SupersededError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("commit %s has been superseded by %s (force-pushed) and no longer exists", e.Commit, e.SupersededBy)
+	}
+	return fmt.Sprintf("commit %s has been superseded by %s (force-pushed), set fetch_force_pushed to build it anyway", e.Commit, e.SupersededBy)
+}
+
+// timeoutError replaces err with a descriptive error naming the operation
+// if it failed because the deadline of the context was exceeded.
+func timeoutError(ctx context.Context, operation string, err error) error {
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s timed out: %s", operation, ctx.Err())
+	}
+	return err
+}
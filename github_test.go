@@ -0,0 +1,320 @@
+package resource_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	resource "github.com/telia-oss/github-pr-resource"
+)
+
+// newTestSource returns a source using the test server for both the V3 and V4 API.
+func newTestSource(server *httptest.Server) resource.Source {
+	return resource.Source{
+		Repository:  "itsdalmo/test-repository",
+		AccessToken: "oauthtoken",
+		V3Endpoint:  server.URL + "/",
+		V4Endpoint:  server.URL + "/graphql",
+	}
+}
+
+func TestGithubClientTimeout(t *testing.T) {
+	tests := []struct {
+		description   string
+		call          func(*resource.GithubClient, resource.Source) error
+		expectedError string
+	}{
+		{
+			description: "post comment names the operation",
+			call: func(c *resource.GithubClient, s resource.Source) error {
+				ctx, cancel := s.Context()
+				defer cancel()
+				return c.PostComment(ctx, "1", "comment")
+			},
+			expectedError: "post comment timed out: context deadline exceeded",
+		},
+		{
+			description: "update commit status names the operation",
+			call: func(c *resource.GithubClient, s resource.Source) error {
+				ctx, cancel := s.Context()
+				defer cancel()
+				return c.UpdateCommitStatus(ctx, "sha", "", "", "success", "", "")
+			},
+			expectedError: "update commit status timed out: context deadline exceeded",
+		},
+		{
+			description: "get pull request names the operation",
+			call: func(c *resource.GithubClient, s resource.Source) error {
+				ctx, cancel := s.Context()
+				defer cancel()
+				_, err := c.GetPullRequest(ctx, "1", "sha")
+				return err
+			},
+			expectedError: "get pull request timed out: context deadline exceeded",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			// Never respond before the client gives up.
+			done := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-done
+			}))
+			defer server.Close()
+			defer close(done)
+
+			source := newTestSource(server)
+			source.Timeout = "50ms"
+
+			client, err := resource.NewGithubClient(&source)
+			require.NoError(t, err)
+
+			err = tc.call(client, source)
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestGithubClientTLS(t *testing.T) {
+	tests := []struct {
+		description         string
+		caBundle            func(*httptest.Server) string
+		skipSSLVerification bool
+		expectedError       string
+	}{
+		{
+			description: "requests fail for untrusted certificates",
+			caBundle: func(*httptest.Server) string {
+				return createTestCertificate(t)
+			},
+			expectedError: "certificate signed by unknown authority",
+		},
+		{
+			description: "ca bundle is trusted",
+			caBundle: func(server *httptest.Server) string {
+				return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+			},
+		},
+		{
+			description: "skip ssl verification is respected with a ca bundle",
+			caBundle: func(*httptest.Server) string {
+				return createTestCertificate(t)
+			},
+			skipSSLVerification: true,
+		},
+		{
+			description: "ca bundle must contain certificates",
+			caBundle: func(*httptest.Server) string {
+				return "not a certificate"
+			},
+			expectedError: "failed to parse certificates in ca_bundle",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			source := newTestSource(server)
+			source.CABundle = tc.caBundle(server)
+			source.SkipSSLVerification = tc.skipSSLVerification
+
+			client, err := resource.NewGithubClient(&source)
+			if err == nil {
+				err = client.PostComment(context.TODO(), "1", "comment")
+			}
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGithubClientProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	source := resource.Source{
+		Repository:  "itsdalmo/test-repository",
+		AccessToken: "oauthtoken",
+		V3Endpoint:  "http://github.example.com/",
+		V4Endpoint:  "http://github.example.com/graphql",
+		ProxyURL:    proxy.URL,
+	}
+
+	client, err := resource.NewGithubClient(&source)
+	require.NoError(t, err)
+	require.NoError(t, client.PostComment(context.TODO(), "1", "comment"))
+
+	assert.Equal(t, []string{"http://github.example.com/repos/itsdalmo/test-repository/issues/1/comments"}, proxied)
+}
+
+// createTestCertificate returns a self-signed certificate (PEM) which is not used by any server.
+func createTestCertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestGetPullRequest(t *testing.T) {
+	tests := []struct {
+		description         string
+		commitRef           string
+		pages               map[string]string
+		commit              string
+		expectedTip         string
+		expectedCommits     []string
+		expectedForcePushed bool
+		expectedError       string
+		expectedSuperseded  *resource.SupersededError
+	}{
+		{
+			description: "get pull request paginates commits",
+			commitRef:   "oid2",
+			pages: map[string]string{
+				"":        pullRequestPage([]string{"oid3", "oid4"}, "cursor1", true),
+				"cursor1": pullRequestPage([]string{"oid1", "oid2"}, "cursor0", false),
+			},
+			expectedTip:     "oid2",
+			expectedCommits: []string{"oid1", "oid2"},
+		},
+		{
+			description: "get pull request looks up force-pushed commits",
+			commitRef:   "old1",
+			pages: map[string]string{
+				"": pullRequestPage([]string{"oid1", "oid2"}, "cursor0", false),
+			},
+			commit:              `{"data":{"repository":{"object":{"oid":"old1","associatedPullRequests":{"edges":[{"node":{"number":2}},{"node":{"number":1}}]}},"pullRequest":{"headRepository":{"object":null}}}}}`,
+			expectedTip:         "old1",
+			expectedForcePushed: true,
+		},
+		{
+			description: "get pull request looks up force-pushed commits in forks",
+			commitRef:   "old1",
+			pages: map[string]string{
+				"": pullRequestPage([]string{"oid1", "oid2"}, "cursor0", false),
+			},
+			commit:              `{"data":{"repository":{"object":null,"pullRequest":{"headRepository":{"object":{"oid":"old1","associatedPullRequests":{"edges":[{"node":{"number":1}}]}}}}}}}`,
+			expectedTip:         "old1",
+			expectedForcePushed: true,
+		},
+		{
+			description: "get pull request fails for commits which do not belong to the pull request",
+			commitRef:   "other1",
+			pages: map[string]string{
+				"": pullRequestPage([]string{"oid1", "oid2"}, "cursor0", false),
+			},
+			commit:        `{"data":{"repository":{"object":{"oid":"other1","associatedPullRequests":{"edges":[{"node":{"number":2}}]}},"pullRequest":{"headRepository":null}}}}`,
+			expectedError: "commit with ref 'other1' does not belong to pull request 1",
+		},
+		{
+			description: "get pull request fails for commits which do not exist",
+			commitRef:   "missing1",
+			pages: map[string]string{
+				"": pullRequestPage([]string{"oid1", "oid2"}, "cursor0", false),
+			},
+			commit:             `{"data":{"repository":{"object":null,"pullRequest":{"headRepository":{"object":null}}}}}`,
+			expectedError:      "commit missing1 has been superseded by oid4 (force-pushed) and no longer exists",
+			expectedSuperseded: &resource.SupersededError{Commit: "missing1", SupersededBy: "oid4", Missing: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request struct {
+					Query     string
+					Variables map[string]interface{}
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+				if strings.Contains(request.Query, "object(oid:$commitOid)") {
+					w.Write([]byte(tc.commit))
+					return
+				}
+				cursor, _ := request.Variables["commitsCursor"].(string)
+				page, ok := tc.pages[cursor]
+				assert.True(t, ok, "unexpected cursor: %s", cursor)
+				w.Write([]byte(page))
+			}))
+			defer server.Close()
+
+			source := newTestSource(server)
+			client, err := resource.NewGithubClient(&source)
+			require.NoError(t, err)
+
+			pull, err := client.GetPullRequest(context.TODO(), "1", tc.commitRef)
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				var superseded *resource.SupersededError
+				errors.As(err, &superseded)
+				assert.Equal(t, tc.expectedSuperseded, superseded)
+				return
+			}
+			require.NoError(t, err)
+
+			var commits []string
+			for _, c := range pull.Commits {
+				commits = append(commits, c.OID)
+			}
+			assert.Equal(t, tc.expectedTip, pull.Tip.OID)
+			assert.Equal(t, tc.expectedCommits, commits)
+			assert.Equal(t, tc.expectedForcePushed, pull.ForcePushed)
+		})
+	}
+}
+
+// pullRequestPage returns a GraphQL response for pull request 1 with a page of commits.
+func pullRequestPage(oids []string, startCursor string, hasPreviousPage bool) string {
+	edges := make([]string, 0, len(oids))
+	for _, oid := range oids {
+		edges = append(edges, fmt.Sprintf(`{"node":{"commit":{"oid":%q}}}`, oid))
+	}
+	return fmt.Sprintf(`{"data":{"repository":{"pullRequest":{"number":1,"headRefOid":"oid4","commits":{"edges":[%s],"pageInfo":{"startCursor":%q,"hasPreviousPage":%t}},"labels":{"edges":[]},"reviewRequests":{"edges":[]},"assignees":{"edges":[]}}}}}`,
+		strings.Join(edges, ","), startCursor, hasPreviousPage)
+}
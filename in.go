@@ -1,68 +1,181 @@
 package resource
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"gopkg.in/yaml.v2"
 )
 
 // Get (business logic)
-func Get(request GetRequest, github Github, git Git, outputDir string) (*GetResponse, error) {
+func Get(ctx context.Context, request GetRequest, github Github, git Git, outputDir string) (*GetResponse, error) {
 	if request.Params.SkipDownload {
 		return &GetResponse{Version: request.Version}, nil
 	}
 
-	pull, err := github.GetPullRequest(request.Version.PR, request.Version.Commit)
+	// The commit has been superseded if it was force-pushed away from the pull request after check.
+	pull, err := github.GetPullRequest(ctx, request.Version.PR, request.Version.Commit)
 	if err != nil {
+		var superseded *SupersededError
+		if errors.As(err, &superseded) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to retrieve pull request: %s", err)
 	}
+	if pull.ForcePushed && !request.Params.FetchForcePushed {
+		return nil, &SupersededError{Commit: pull.Tip.OID, SupersededBy: pull.HeadRefOID}
+	}
+
+	// The commits of the pull request up to a force-pushed commit are unknown.
+	if pull.ForcePushed {
+		switch p := request.Params; {
+		case p.VerifySignatures == "all":
+			return nil, commitsUnknownError(pull, "verify_signatures: all")
+		case p.RequireSignOff:
+			return nil, commitsUnknownError(pull, "require_signoff")
+		case p.ListCommits:
+			return nil, commitsUnknownError(pull, "list_commits")
+		case p.WritePatches:
+			return nil, commitsUnknownError(pull, "write_patches")
+		}
+	}
+
+	// Verify the signatures before spending time on cloning the repository.
+	if err := verifySignatures(pull, request.Params.VerifySignatures); err != nil {
+		return nil, err
+	}
+
+	if request.Params.RequireSignOff {
+		if err := checkSignOffs(ctx, github, pull, request.Params.SignOffStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	// The merge commit is only created by Github once it knows that the PR can be merged.
+	if request.Params.IntegrationTool == "github_merge" {
+		if pull, err = waitForMergeable(ctx, github, request.Version, pull); err != nil {
+			return nil, err
+		}
+	}
 
 	// Initialize and pull the base for the PR
-	if err := git.Init(pull.BaseRefName); err != nil {
+	if err := git.Init(ctx, pull.BaseRefName); err != nil {
 		return nil, err
 	}
-	if err := git.Pull(pull.Repository.URL, pull.BaseRefName, request.Params.GitDepth, request.Params.Submodules); err != nil {
+	if len(request.Params.SparseCheckout) > 0 {
+		if err := git.SparseCheckout(ctx, request.Params.SparseCheckout); err != nil {
+			return nil, err
+		}
+	}
+	// Pull the base at the commit recorded by check (if any) so that the result is reproducible.
+	baseRef := pull.BaseRefName
+	if request.Version.BaseCommit != "" {
+		baseRef = request.Version.BaseCommit
+	}
+	if err := git.Pull(ctx, pull.Repository.URL, baseRef, request.Params.GitDepth, request.Params.Submodules, request.Params.PartialClone); err != nil {
 		return nil, err
 	}
 
 	// Get the last commit SHA in base for the metadata
-	baseSHA, err := git.RevParse(pull.BaseRefName)
+	baseSHA, err := git.RevParse(ctx, pull.BaseRefName)
 	if err != nil {
 		return nil, err
 	}
 
-	// Fetch the PR and merge the specified commit into the base
-	if err := git.Fetch(pull.Repository.URL, pull.Number, request.Params.GitDepth, request.Params.Submodules); err != nil {
+	// Fetch the PR and merge the specified commit into the base. A force-pushed commit
+	// is not part of the pull request anymore, so we fetch it directly instead.
+	if pull.ForcePushed {
+		err = git.FetchCommit(ctx, pull.Repository.URL, pull.Tip.OID, request.Params.GitDepth, request.Params.Submodules, request.Params.PartialClone)
+		// The commit might only exist in the fork for cross-repository pull requests.
+		if err != nil && pull.IsCrossRepository {
+			err = git.FetchCommit(ctx, pull.HeadRepository.URL, pull.Tip.OID, request.Params.GitDepth, request.Params.Submodules, request.Params.PartialClone)
+		}
+	} else {
+		err = git.Fetch(ctx, pull.Repository.URL, pull.Number, request.Params.GitDepth, request.Params.Submodules, request.Params.PartialClone)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	switch tool := request.Params.IntegrationTool; tool {
 	case "rebase":
-		if err := git.Rebase(pull.BaseRefName, pull.Tip.OID, request.Params.Submodules); err != nil {
-			return nil, err
+		if err := git.Rebase(ctx, pull.BaseRefName, pull.Tip.OID, request.Params.Submodules); err != nil {
+			return nil, reportConflicts(ctx, request, github, git, pull, outputDir, err)
 		}
 	case "merge", "":
-		if err := git.Merge(pull.Tip.OID, request.Params.Submodules); err != nil {
-			return nil, err
+		if err := git.Merge(ctx, pull.Tip.OID, request.Params.Submodules); err != nil {
+			return nil, reportConflicts(ctx, request, github, git, pull, outputDir, err)
+		}
+	case "squash":
+		// Use the same commit message as a squash merge on Github.
+		message := fmt.Sprintf("%s (#%d)", pull.Title, pull.Number)
+		if err := git.Squash(ctx, pull.Tip.OID, message, request.Params.Submodules); err != nil {
+			return nil, reportConflicts(ctx, request, github, git, pull, outputDir, err)
 		}
 	case "checkout":
-		if err := git.Checkout(pull.HeadRefName, pull.Tip.OID, request.Params.Submodules); err != nil {
+		if err := git.Checkout(ctx, pull.HeadRefName, pull.Tip.OID, request.Params.Submodules); err != nil {
 			return nil, err
 		}
+	case "github_merge":
+		if err := git.FetchMerge(ctx, pull.Repository.URL, pull.Number, request.Params.GitDepth, request.Params.Submodules, request.Params.PartialClone); err != nil {
+			return nil, err
+		}
+		parents, err := git.Parents(ctx, "FETCH_HEAD")
+		if err != nil {
+			return nil, err
+		}
+		if len(parents) != 2 || parents[1] != pull.Tip.OID {
+			return nil, fmt.Errorf("merge commit from github is not for commit %s (parents: %s)", pull.Tip.OID, strings.Join(parents, ", "))
+		}
+		// Github merges into the current base, which is not reproducible if the version pins another base commit.
+		// In that case we fall back to merging locally into the pinned base commit.
+		if base := request.Version.BaseCommit; base != "" && parents[0] != base {
+			if err := git.Merge(ctx, pull.Tip.OID, request.Params.Submodules); err != nil {
+				return nil, reportConflicts(ctx, request, github, git, pull, outputDir, err)
+			}
+			break
+		}
+		if err := git.Reset(ctx, "FETCH_HEAD", request.Params.Submodules); err != nil {
+			return nil, err
+		}
+		// Github might have merged into another commit in the base than the one we pulled.
+		baseSHA = parents[0]
 	default:
 		return nil, fmt.Errorf("invalid integration tool specified: %s", tool)
 	}
 
+	// Verify the signatures against the keyring in the source configuration now that the commits are fetched.
+	if request.Source.SignatureKeyring != "" {
+		if err := verifyLocalSignatures(ctx, git, pull, request.Params.VerifySignatures, request.Source.SignatureKeyring); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.Params.LFS {
+		if err := git.LFSPull(ctx, pull.Repository.URL, request.Params.LFSInclude, request.Params.LFSExclude); err != nil {
+			return nil, err
+		}
+	}
+
 	if request.Source.GitCryptKey != "" {
-		if err := git.GitCryptUnlock(request.Source.GitCryptKey); err != nil {
+		if err := git.GitCryptUnlock(ctx, request.Source.GitCryptKey); err != nil {
 			return nil, err
 		}
 	}
 
 	// Create the metadata
+	document := newPullRequestMetadata(pull, baseSHA)
+
 	var metadata Metadata
 	metadata.Add("pr", strconv.Itoa(pull.Number))
 	metadata.Add("title", pull.Title)
@@ -74,6 +187,15 @@ func Get(request GetRequest, github Github, git Git, outputDir string) (*GetResp
 	metadata.Add("message", pull.Tip.Message)
 	metadata.Add("author", pull.Tip.Author.User.Login)
 	metadata.Add("author_email", pull.Tip.Author.Email)
+	metadata.Add("labels", strings.Join(document.Labels, ","))
+	metadata.Add("requested_reviewers", strings.Join(pull.RequestedReviewers, ","))
+	metadata.Add("assignees", strings.Join(pull.Assignees, ","))
+	metadata.Add("milestone", pull.Milestone.Title)
+	metadata.Add("created_at", pull.CreatedAt.Format(time.RFC3339))
+	metadata.Add("updated_at", pull.UpdatedAt.Format(time.RFC3339))
+	if pull.ForcePushed {
+		metadata.Add("superseded_by", pull.HeadRefOID)
+	}
 
 	// Write version and metadata for reuse in PUT
 	path := filepath.Join(outputDir, ".git", "resource")
@@ -103,23 +225,114 @@ func Get(request GetRequest, github Github, git Git, outputDir string) (*GetResp
 		}
 
 	}
+	// The body is written to a file, but left out of the metadata since it would clutter the Concourse UI.
+	if err := ioutil.WriteFile(filepath.Join(path, "body"), []byte(pull.Body), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write metadata file body: %s", err)
+	}
 
+	var changedFiles []string
 	if request.Params.ListChangedFiles {
-		cfol, err := github.GetChangedFiles(request.Version.PR, request.Version.Commit)
+		cfol, err := github.GetChangedFiles(ctx, request.Version.PR, request.Version.Commit)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch list of changed files: %s", err)
 		}
+		if request.Params.FilterChangedFiles && len(request.Source.Paths) > 0 {
+			if cfol, err = filterChangedFiles(cfol, request.Source.Paths); err != nil {
+				return nil, fmt.Errorf("failed to filter changed files: %s", err)
+			}
+		}
 
 		var fl []byte
 
 		for _, v := range cfol {
 			fl = append(fl, []byte(v.Path+"\n")...)
+			changedFiles = append(changedFiles, v.Path)
 		}
 
 		// Create List with changed files
 		if err := ioutil.WriteFile(filepath.Join(path, "changed_files"), fl, 0644); err != nil {
 			return nil, fmt.Errorf("failed to write file list: %s", err)
 		}
+
+		// Use an empty list (instead of null) if no files changed.
+		if cfol == nil {
+			cfol = []ChangedFileObject{}
+		}
+		b, err = json.Marshal(cfol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal changed files: %s", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(path, "changed_files.json"), b, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write changed files: %s", err)
+		}
+	}
+
+	if request.Params.WriteDiff {
+		diff, err := github.GetDiff(ctx, baseSHA, pull.Tip.OID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get diff: %s", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(path, "pr.diff"), []byte(diff), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write diff: %s", err)
+		}
+	}
+
+	if request.Params.ListCommits {
+		commits := make([]CommitMetadata, 0, len(pull.Commits))
+		for _, c := range pull.Commits {
+			commits = append(commits, CommitMetadata{
+				SHA:         c.OID,
+				Message:     c.Message,
+				Author:      c.Author.User.Login,
+				AuthorEmail: c.Author.Email,
+				Date:        c.CommittedDate.Time,
+				Verified:    c.Signature.IsValid,
+				Signer:      c.Signature.Signer.Login,
+			})
+		}
+		b, err = json.Marshal(commits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal commits: %s", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(path, "commits.json"), b, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write commits: %s", err)
+		}
+	}
+
+	if request.Params.WritePatches {
+		patches := filepath.Join(path, "patches")
+		if err := os.MkdirAll(patches, os.ModePerm); err != nil {
+			return nil, fmt.Errorf("failed to create patches directory: %s", err)
+		}
+		for _, c := range pull.Commits {
+			patch, err := github.GetPatch(ctx, c.OID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get patch for commit %s: %s", c.OID, err)
+			}
+			if err := ioutil.WriteFile(filepath.Join(patches, c.OID+".patch"), []byte(patch), 0644); err != nil {
+				return nil, fmt.Errorf("failed to write patch: %s", err)
+			}
+		}
+	}
+
+	// Write the metadata as a single document (JSON and YAML) and as environment variables
+	document.ChangedFiles = changedFiles
+	b, err = json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pull request metadata: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "pr.json"), b, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write pull request metadata: %s", err)
+	}
+	b, err = yaml.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pull request metadata to yaml: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "pr.yml"), b, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write pull request metadata as yaml: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "env"), []byte(document.Env()), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write environment file: %s", err)
 	}
 
 	return &GetResponse{
@@ -128,13 +341,267 @@ func Get(request GetRequest, github Github, git Git, outputDir string) (*GetResp
 	}, nil
 }
 
+// commitsUnknownError is returned for parameters which need the commits of the pull request, when only the
+// (force-pushed) commit itself is known.
+func commitsUnknownError(pull *PullRequest, parameter string) error {
+	return fmt.Errorf("%s is not supported for commit %s, its commits are unknown since it was force-pushed", parameter, pull.Tip.OID)
+}
+
+// verifySignatures checks that the head commit (or all commits) in the pull request have a
+// signature which has been verified by Github, and lists the offending commits otherwise.
+func verifySignatures(pull *PullRequest, mode string) error {
+	commits, err := signedCommits(pull, mode)
+	if err != nil {
+		return err
+	}
+
+	var offenders []string
+	for _, c := range commits {
+		if c.Signature.IsValid {
+			continue
+		}
+		reason := "unsigned"
+		if state := c.Signature.State; state != "" && state != githubv4.GitSignatureStateUnsigned {
+			reason = strings.ToLower(string(state))
+		}
+		offenders = append(offenders, fmt.Sprintf("%s (%s)", c.OID, reason))
+	}
+	if len(offenders) > 0 {
+		return fmt.Errorf("pull request has commits without a verified signature: %s", strings.Join(offenders, ", "))
+	}
+	return nil
+}
+
+// verifyLocalSignatures checks the signatures of the head commit (or all commits) in the pull request
+// against the given keyring using git, and lists the offending commits otherwise.
+func verifyLocalSignatures(ctx context.Context, git Git, pull *PullRequest, mode, keyring string) error {
+	commits, err := signedCommits(pull, mode)
+	if err != nil {
+		return err
+	}
+
+	var offenders []string
+	for _, c := range commits {
+		if err := git.VerifyCommit(ctx, keyring, c.OID); err != nil {
+			offenders = append(offenders, fmt.Sprintf("%s (%s)", c.OID, err))
+		}
+	}
+	if len(offenders) > 0 {
+		return fmt.Errorf("pull request has commits without a signature from signature_keyring: %s", strings.Join(offenders, ", "))
+	}
+	return nil
+}
+
+// signedCommits returns the commits which must be signed for the signature verification mode.
+func signedCommits(pull *PullRequest, mode string) ([]CommitObject, error) {
+	switch mode {
+	case "":
+		return nil, nil
+	case "head":
+		return []CommitObject{pull.Tip}, nil
+	case "all":
+		return pull.Commits, nil
+	}
+	return nil, fmt.Errorf("invalid signature verification specified: %s", mode)
+}
+
+// signOffPattern matches Signed-off-by trailers and captures the email.
+var signOffPattern = regexp.MustCompile(`(?im)^Signed-off-by:.*<([^>]+)>\s*$`)
+
+// checkSignOffs verifies that every commit in the pull request has been signed off by the author (Developer
+// Certificate of Origin), and optionally reports the result as a commit status on the head of the pull request.
+func checkSignOffs(ctx context.Context, github Github, pull *PullRequest, status bool) error {
+	var checked int
+	var offenders []string
+	for _, c := range pull.Commits {
+		// Merge commits (e.g. from "Update branch" on Github) are not signed off.
+		if isMergeCommit(c) {
+			continue
+		}
+		checked++
+		if reason := missingSignOff(c); reason != "" {
+			offenders = append(offenders, fmt.Sprintf("%s (%s)", c.OID, reason))
+		}
+	}
+
+	if status {
+		state, description := "success", "All commits are signed off"
+		if len(offenders) > 0 {
+			state, description = "failure", fmt.Sprintf("%d of %d commits are missing a sign-off", len(offenders), checked)
+		}
+		if err := github.UpdateCommitStatus(ctx, pull.Tip.OID, "", "dco", state, "", description); err != nil {
+			return fmt.Errorf("failed to set sign-off status: %s", err)
+		}
+	}
+
+	if len(offenders) > 0 {
+		return fmt.Errorf("pull request has commits without a sign-off from the author: %s", strings.Join(offenders, ", "))
+	}
+	return nil
+}
+
+// isMergeCommit reports whether the commit is a merge, based on the default message used by git and Github.
+func isMergeCommit(c CommitObject) bool {
+	return strings.HasPrefix(c.Message, "Merge ")
+}
+
+// missingSignOff returns the reason a commit is not signed off by its author, or an empty string if it is.
+func missingSignOff(c CommitObject) string {
+	matches := signOffPattern.FindAllStringSubmatch(c.Message, -1)
+	if len(matches) == 0 {
+		return "no sign-off"
+	}
+	for _, m := range matches {
+		if strings.EqualFold(strings.TrimSpace(m[1]), c.Author.Email) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("sign-off does not match author %s", c.Author.Email)
+}
+
+// filterChangedFiles only keeps the files that match one of the patterns. Renamed files
+// are also kept if they were moved away from a matching path.
+func filterChangedFiles(files []ChangedFileObject, patterns []string) ([]ChangedFileObject, error) {
+	var out []ChangedFileObject
+	for _, f := range files {
+		paths := []string{f.Path}
+		if f.PreviousPath != "" {
+			paths = append(paths, f.PreviousPath)
+		}
+		for _, pattern := range patterns {
+			matches, err := FilterPath(paths, pattern)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) > 0 {
+				out = append(out, f)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// newPullRequestMetadata creates the typed metadata document for a pull request.
+func newPullRequestMetadata(pull *PullRequest, baseSHA string) *PullRequestMetadata {
+	labels := make([]string, 0, len(pull.Labels))
+	for _, l := range pull.Labels {
+		labels = append(labels, l.Name)
+	}
+	// Use empty lists (instead of null) in the JSON document.
+	reviewers := append([]string{}, pull.RequestedReviewers...)
+	assignees := append([]string{}, pull.Assignees...)
+	m := &PullRequestMetadata{
+		Number: pull.Number,
+		Title:  pull.Title,
+		URL:    pull.URL,
+		Head: RefMetadata{
+			Name:       pull.HeadRefName,
+			SHA:        pull.Tip.OID,
+			Repository: pull.HeadRepository.URL,
+		},
+		Base: RefMetadata{
+			Name:       pull.BaseRefName,
+			SHA:        baseSHA,
+			Repository: pull.Repository.URL,
+		},
+		Message:            pull.Tip.Message,
+		Author:             pull.Tip.Author.User.Login,
+		AuthorEmail:        pull.Tip.Author.Email,
+		IsCrossRepository:  pull.IsCrossRepository,
+		Body:               pull.Body,
+		Labels:             labels,
+		RequestedReviewers: reviewers,
+		Assignees:          assignees,
+		Milestone:          pull.Milestone.Title,
+		CreatedAt:          pull.CreatedAt.Time,
+		UpdatedAt:          pull.UpdatedAt.Time,
+	}
+	if pull.ForcePushed {
+		m.SupersededBy = pull.HeadRefOID
+	}
+	return m
+}
+
+// reportConflicts checks whether integrating the pull request failed due to merge conflicts. If so, the
+// conflicting files are written to the output directory and (optionally) posted as a comment on the PR.
+func reportConflicts(ctx context.Context, request GetRequest, github Github, git Git, pull *PullRequest, outputDir string, err error) error {
+	files, cerr := git.Conflicts(ctx)
+	if cerr != nil || len(files) == 0 {
+		return err
+	}
+
+	path := filepath.Join(outputDir, ".git", "resource")
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create output directory: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "conflicts"), []byte(strings.Join(files, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write conflicts: %s", err)
+	}
+
+	if request.Params.CommentOnConflicts {
+		comment := fmt.Sprintf("This pull request has merge conflicts with `%s` in the following files:\n\n", pull.BaseRefName)
+		for _, f := range files {
+			comment += fmt.Sprintf("- `%s`\n", f)
+		}
+		comment += "\nPlease rebase or merge the latest changes from the base branch."
+		if err := github.PostComment(ctx, request.Version.PR, comment); err != nil {
+			return fmt.Errorf("failed to post comment about merge conflicts: %s", err)
+		}
+	}
+
+	return fmt.Errorf("pull request has merge conflicts with %s in: %s", pull.BaseRefName, strings.Join(files, ", "))
+}
+
+const (
+	mergeablePollInterval = 5 * time.Second
+	mergeablePollAttempts = 12
+)
+
+// waitForMergeable polls Github until it has finished computing whether the pull request can be merged.
+func waitForMergeable(ctx context.Context, github Github, version Version, pull *PullRequest) (*PullRequest, error) {
+	for attempt := 1; pull.Mergeable == githubv4.MergeableStateUnknown; attempt++ {
+		if attempt > mergeablePollAttempts {
+			return nil, errors.New("github is still computing whether the pull request can be merged, try again later")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, timeoutError(ctx, "wait for mergeable state", ctx.Err())
+		case <-time.After(mergeablePollInterval):
+		}
+
+		var err error
+		if pull, err = github.GetPullRequest(ctx, version.PR, version.Commit); err != nil {
+			return nil, fmt.Errorf("failed to retrieve pull request: %s", err)
+		}
+	}
+	if pull.Mergeable == githubv4.MergeableStateConflicting {
+		return nil, errors.New("pull request has merge conflicts, github has not created a merge commit")
+	}
+	return pull, nil
+}
+
 // GetParameters ...
 type GetParameters struct {
-	SkipDownload     bool   `json:"skip_download"`
-	IntegrationTool  string `json:"integration_tool"`
-	GitDepth         int    `json:"git_depth"`
-	Submodules       bool   `json:"submodules"`
-	ListChangedFiles bool   `json:"list_changed_files"`
+	SkipDownload       bool     `json:"skip_download"`
+	IntegrationTool    string   `json:"integration_tool"`
+	GitDepth           int      `json:"git_depth"`
+	Submodules         bool     `json:"submodules"`
+	ListChangedFiles   bool     `json:"list_changed_files"`
+	FilterChangedFiles bool     `json:"filter_changed_files"`
+	FetchForcePushed   bool     `json:"fetch_force_pushed"`
+	VerifySignatures   string   `json:"verify_signatures"`
+	RequireSignOff     bool     `json:"require_signoff"`
+	SignOffStatus      bool     `json:"signoff_status"`
+	WriteDiff          bool     `json:"write_diff"`
+	ListCommits        bool     `json:"list_commits"`
+	WritePatches       bool     `json:"write_patches"`
+	LFS                bool     `json:"lfs"`
+	LFSInclude         []string `json:"lfs_include"`
+	LFSExclude         []string `json:"lfs_exclude"`
+	SparseCheckout     []string `json:"sparse_checkout"`
+	PartialClone       bool     `json:"partial_clone"`
+	CommentOnConflicts bool     `json:"comment_on_conflicts"`
 }
 
 // GetRequest ...
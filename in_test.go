@@ -1,6 +1,8 @@
 package resource_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -42,7 +44,24 @@ func TestGet(t *testing.T) {
 			parameters:     resource.GetParameters{},
 			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
 			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"}]`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
+		},
+		{
+			description: "get uses the base commit from the version",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version: resource.Version{
+				PR:            "pr1",
+				Commit:        "commit1",
+				CommittedDate: time.Time{},
+				BaseCommit:    "base1",
+			},
+			parameters:     resource.GetParameters{},
+			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
+			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z","base_commit":"base1"}`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
 		},
 		{
 			description: "get supports unlocking with git crypt",
@@ -59,7 +78,7 @@ func TestGet(t *testing.T) {
 			parameters:     resource.GetParameters{},
 			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
 			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"}]`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
 		},
 		{
 			description: "get supports rebasing",
@@ -77,7 +96,7 @@ func TestGet(t *testing.T) {
 			},
 			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
 			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"}]`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
 		},
 		{
 			description: "get supports checkout",
@@ -95,7 +114,62 @@ func TestGet(t *testing.T) {
 			},
 			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
 			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"}]`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
+		},
+		{
+			description: "get supports squashing",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version: resource.Version{
+				PR:            "pr1",
+				Commit:        "commit1",
+				CommittedDate: time.Time{},
+			},
+			parameters: resource.GetParameters{
+				IntegrationTool: "squash",
+			},
+			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
+			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
+		},
+		{
+			description: "get supports the merge commit from github",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version: resource.Version{
+				PR:            "pr1",
+				Commit:        "commit1",
+				CommittedDate: time.Time{},
+			},
+			parameters: resource.GetParameters{
+				IntegrationTool: "github_merge",
+			},
+			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
+			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
+		},
+		{
+			description: "get merges locally when the merge commit from github is against another base commit",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version: resource.Version{
+				PR:            "pr1",
+				Commit:        "commit1",
+				CommittedDate: time.Time{},
+				BaseCommit:    "base1",
+			},
+			parameters: resource.GetParameters{
+				IntegrationTool: "github_merge",
+			},
+			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
+			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z","base_commit":"base1"}`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
 		},
 		{
 			description: "get supports git_depth",
@@ -113,7 +187,7 @@ func TestGet(t *testing.T) {
 			},
 			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
 			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"}]`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
 		},
 		{
 			description: "get supports list_changed_files",
@@ -139,9 +213,48 @@ func TestGet(t *testing.T) {
 				},
 			},
 			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
-			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"}]`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
 			filesString:    "README.md\nOther.md\n",
 		},
+		{
+			description: "get supports sparse checkout and partial clone",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version: resource.Version{
+				PR:            "pr1",
+				Commit:        "commit1",
+				CommittedDate: time.Time{},
+			},
+			parameters: resource.GetParameters{
+				SparseCheckout: []string{"services/foo"},
+				PartialClone:   true,
+			},
+			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
+			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
+		},
+		{
+			description: "get supports git lfs",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version: resource.Version{
+				PR:            "pr1",
+				Commit:        "commit1",
+				CommittedDate: time.Time{},
+			},
+			parameters: resource.GetParameters{
+				LFS:        true,
+				LFSInclude: []string{"assets/"},
+				LFSExclude: []string{"assets/videos/"},
+			},
+			pullRequest:    createTestPR(1, "master", false, false, 0, nil),
+			versionString:  `{"pr":"pr1","commit":"commit1","committed":"0001-01-01T00:00:00Z"}`,
+			metadataString: `[{"name":"pr","value":"1"},{"name":"title","value":"pr1 title"},{"name":"url","value":"pr1 url"},{"name":"head_name","value":"pr1"},{"name":"head_sha","value":"oid1"},{"name":"base_name","value":"master"},{"name":"base_sha","value":"sha"},{"name":"message","value":"commit message1"},{"name":"author","value":"login1"},{"name":"author_email","value":"user@example.com"},{"name":"labels","value":""},{"name":"requested_reviewers","value":""},{"name":"assignees","value":""},{"name":"milestone","value":""},{"name":"created_at","value":"2020-01-01T00:00:00Z"},{"name":"updated_at","value":"2020-01-02T00:00:00Z"}]`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -155,12 +268,13 @@ func TestGet(t *testing.T) {
 
 			git := new(fakes.FakeGit)
 			git.RevParseReturns("sha", nil)
+			git.ParentsReturns([]string{"sha", tc.pullRequest.Tip.OID}, nil)
 
 			dir := createTestDirectory(t)
 			defer os.RemoveAll(dir)
 
 			input := resource.GetRequest{Source: tc.source, Version: tc.version, Params: tc.parameters}
-			output, err := resource.Get(input, github, git, dir)
+			output, err := resource.Get(context.TODO(), input, github, git, dir)
 
 			// Validate output
 			if assert.NoError(t, err) {
@@ -200,63 +314,117 @@ func TestGet(t *testing.T) {
 
 			// Validate Github calls
 			if assert.Equal(t, 1, github.GetPullRequestCallCount()) {
-				pr, commit := github.GetPullRequestArgsForCall(0)
+				_, pr, commit := github.GetPullRequestArgsForCall(0)
 				assert.Equal(t, tc.version.PR, pr)
 				assert.Equal(t, tc.version.Commit, commit)
 			}
 
 			// Validate Git calls
 			if assert.Equal(t, 1, git.InitCallCount()) {
-				base := git.InitArgsForCall(0)
+				_, base := git.InitArgsForCall(0)
 				assert.Equal(t, tc.pullRequest.BaseRefName, base)
 			}
 
 			if assert.Equal(t, 1, git.PullCallCount()) {
-				url, base, depth, submodules := git.PullArgsForCall(0)
+				_, url, base, depth, submodules, partialClone := git.PullArgsForCall(0)
 				assert.Equal(t, tc.pullRequest.Repository.URL, url)
-				assert.Equal(t, tc.pullRequest.BaseRefName, base)
+				if tc.version.BaseCommit != "" {
+					assert.Equal(t, tc.version.BaseCommit, base)
+				} else {
+					assert.Equal(t, tc.pullRequest.BaseRefName, base)
+				}
 				assert.Equal(t, tc.parameters.GitDepth, depth)
 				assert.Equal(t, tc.parameters.Submodules, submodules)
+				assert.Equal(t, tc.parameters.PartialClone, partialClone)
 			}
 
 			if assert.Equal(t, 1, git.RevParseCallCount()) {
-				base := git.RevParseArgsForCall(0)
+				_, base := git.RevParseArgsForCall(0)
 				assert.Equal(t, tc.pullRequest.BaseRefName, base)
 			}
 
 			if assert.Equal(t, 1, git.FetchCallCount()) {
-				url, pr, depth, submodules := git.FetchArgsForCall(0)
+				_, url, pr, depth, submodules, partialClone := git.FetchArgsForCall(0)
 				assert.Equal(t, tc.pullRequest.Repository.URL, url)
 				assert.Equal(t, tc.pullRequest.Number, pr)
 				assert.Equal(t, tc.parameters.GitDepth, depth)
 				assert.Equal(t, tc.parameters.Submodules, submodules)
+				assert.Equal(t, tc.parameters.PartialClone, partialClone)
+			}
+
+			if len(tc.parameters.SparseCheckout) > 0 {
+				if assert.Equal(t, 1, git.SparseCheckoutCallCount()) {
+					_, patterns := git.SparseCheckoutArgsForCall(0)
+					assert.Equal(t, tc.parameters.SparseCheckout, patterns)
+				}
+			} else {
+				assert.Equal(t, 0, git.SparseCheckoutCallCount())
 			}
 
 			switch tc.parameters.IntegrationTool {
 			case "rebase":
 				if assert.Equal(t, 1, git.RebaseCallCount()) {
-					branch, tip, submodules := git.RebaseArgsForCall(0)
+					_, branch, tip, submodules := git.RebaseArgsForCall(0)
 					assert.Equal(t, tc.pullRequest.BaseRefName, branch)
 					assert.Equal(t, tc.pullRequest.Tip.OID, tip)
 					assert.Equal(t, tc.parameters.Submodules, submodules)
 				}
+			case "squash":
+				if assert.Equal(t, 1, git.SquashCallCount()) {
+					_, tip, message, submodules := git.SquashArgsForCall(0)
+					assert.Equal(t, tc.pullRequest.Tip.OID, tip)
+					assert.Equal(t, "pr1 title (#1)", message)
+					assert.Equal(t, tc.parameters.Submodules, submodules)
+				}
 			case "checkout":
 				if assert.Equal(t, 1, git.CheckoutCallCount()) {
-					branch, sha, submodules := git.CheckoutArgsForCall(0)
+					_, branch, sha, submodules := git.CheckoutArgsForCall(0)
 					assert.Equal(t, tc.pullRequest.HeadRefName, branch)
 					assert.Equal(t, tc.pullRequest.Tip.OID, sha)
 					assert.Equal(t, tc.parameters.Submodules, submodules)
 				}
+			case "github_merge":
+				if assert.Equal(t, 1, git.FetchMergeCallCount()) {
+					_, url, pr, depth, submodules, partialClone := git.FetchMergeArgsForCall(0)
+					assert.Equal(t, tc.pullRequest.Repository.URL, url)
+					assert.Equal(t, tc.pullRequest.Number, pr)
+					assert.Equal(t, tc.parameters.GitDepth, depth)
+					assert.Equal(t, tc.parameters.Submodules, submodules)
+					assert.Equal(t, tc.parameters.PartialClone, partialClone)
+				}
+				// The fake merge commit is against "sha", so a pinned base commit requires a local merge.
+				if tc.version.BaseCommit != "" {
+					assert.Equal(t, 0, git.ResetCallCount())
+					if assert.Equal(t, 1, git.MergeCallCount()) {
+						_, tip, submodules := git.MergeArgsForCall(0)
+						assert.Equal(t, tc.pullRequest.Tip.OID, tip)
+						assert.Equal(t, tc.parameters.Submodules, submodules)
+					}
+				} else if assert.Equal(t, 1, git.ResetCallCount()) {
+					_, sha, submodules := git.ResetArgsForCall(0)
+					assert.Equal(t, "FETCH_HEAD", sha)
+					assert.Equal(t, tc.parameters.Submodules, submodules)
+				}
 			default:
 				if assert.Equal(t, 1, git.MergeCallCount()) {
-					tip, submodules := git.MergeArgsForCall(0)
+					_, tip, submodules := git.MergeArgsForCall(0)
 					assert.Equal(t, tc.pullRequest.Tip.OID, tip)
 					assert.Equal(t, tc.parameters.Submodules, submodules)
 				}
 			}
+			if tc.parameters.LFS {
+				if assert.Equal(t, 1, git.LFSPullCallCount()) {
+					_, url, include, exclude := git.LFSPullArgsForCall(0)
+					assert.Equal(t, tc.pullRequest.Repository.URL, url)
+					assert.Equal(t, tc.parameters.LFSInclude, include)
+					assert.Equal(t, tc.parameters.LFSExclude, exclude)
+				}
+			} else {
+				assert.Equal(t, 0, git.LFSPullCallCount())
+			}
 			if tc.source.GitCryptKey != "" {
 				if assert.Equal(t, 1, git.GitCryptUnlockCallCount()) {
-					key := git.GitCryptUnlockArgsForCall(0)
+					_, key := git.GitCryptUnlockArgsForCall(0)
 					assert.Equal(t, tc.source.GitCryptKey, key)
 				}
 			}
@@ -264,6 +432,789 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetGithubMergeFails(t *testing.T) {
+	tests := []struct {
+		description string
+		mergeable   githubv4.MergeableState
+		parents     []string
+		expected    string
+	}{
+		{
+			description: "get fails when the pull request has conflicts",
+			mergeable:   githubv4.MergeableStateConflicting,
+			parents:     []string{"sha", "oid1"},
+			expected:    "pull request has merge conflicts, github has not created a merge commit",
+		},
+		{
+			description: "get fails when the merge commit is for another commit",
+			mergeable:   githubv4.MergeableStateMergeable,
+			parents:     []string{"sha", "oid2"},
+			expected:    "merge commit from github is not for commit oid1 (parents: sha, oid2)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pull := createTestPR(1, "master", false, false, 0, nil)
+			pull.Mergeable = tc.mergeable
+
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(pull, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+			git.ParentsReturns(tc.parents, nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"},
+				Version: resource.Version{PR: "pr1", Commit: "commit1"},
+				Params:  resource.GetParameters{IntegrationTool: "github_merge"},
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.expected, err.Error())
+			}
+			assert.Equal(t, 0, git.ResetCallCount())
+		})
+	}
+}
+
+func TestGetConflicts(t *testing.T) {
+	tests := []struct {
+		description          string
+		parameters           resource.GetParameters
+		conflicts            []string
+		expectedError        string
+		expectedConflicts    string
+		expectedComment      string
+		expectedCommentCount int
+	}{
+		{
+			description:       "get reports conflicting files",
+			parameters:        resource.GetParameters{},
+			conflicts:         []string{"README.md", "main.go"},
+			expectedError:     "pull request has merge conflicts with master in: README.md, main.go",
+			expectedConflicts: "README.md\nmain.go\n",
+		},
+		{
+			description:          "get comments on the pull request about conflicts",
+			parameters:           resource.GetParameters{CommentOnConflicts: true},
+			conflicts:            []string{"README.md"},
+			expectedError:        "pull request has merge conflicts with master in: README.md",
+			expectedConflicts:    "README.md\n",
+			expectedComment:      "This pull request has merge conflicts with `master` in the following files:\n\n- `README.md`\n\nPlease rebase or merge the latest changes from the base branch.",
+			expectedCommentCount: 1,
+		},
+		{
+			description:   "get returns the original error when there are no conflicts",
+			parameters:    resource.GetParameters{CommentOnConflicts: true},
+			conflicts:     nil,
+			expectedError: "merge failed: exit status 1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+			git.MergeReturns(errors.New("merge failed: exit status 1"))
+			git.ConflictsReturns(tc.conflicts, nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"},
+				Version: resource.Version{PR: "pr1", Commit: "commit1"},
+				Params:  tc.parameters,
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.expectedError, err.Error())
+			}
+			if tc.expectedConflicts != "" {
+				conflicts := readTestFile(t, filepath.Join(dir, ".git", "resource", "conflicts"))
+				assert.Equal(t, tc.expectedConflicts, conflicts)
+			}
+			if assert.Equal(t, tc.expectedCommentCount, github.PostCommentCallCount()) && tc.expectedCommentCount > 0 {
+				_, pr, comment := github.PostCommentArgsForCall(0)
+				assert.Equal(t, "pr1", pr)
+				assert.Equal(t, tc.expectedComment, comment)
+			}
+		})
+	}
+}
+
+func TestGetMetadataDocuments(t *testing.T) {
+	tests := []struct {
+		description string
+		parameters  resource.GetParameters
+		labels      []string
+		reviewers   []string
+		assignees   []string
+		milestone   string
+		files       []resource.ChangedFileObject
+		expectedPR  string
+		expectedYML string
+		expectedEnv string
+	}{
+		{
+			description: "get writes the metadata as json, yaml and environment variables",
+			parameters:  resource.GetParameters{},
+			expectedPR:  `{"number":1,"title":"pr1 title","url":"pr1 url","head":{"name":"pr1","sha":"oid1","repository":"fork url"},"base":{"name":"master","sha":"sha","repository":"repo1 url"},"message":"commit message1","author":"login1","author_email":"user@example.com","is_cross_repository":false,"body":"pr1 body","labels":[],"requested_reviewers":[],"assignees":[],"milestone":"","created_at":"2020-01-01T00:00:00Z","updated_at":"2020-01-02T00:00:00Z"}`,
+			expectedYML: `number: 1
+title: pr1 title
+url: pr1 url
+head:
+  name: pr1
+  sha: oid1
+  repository: fork url
+base:
+  name: master
+  sha: sha
+  repository: repo1 url
+message: commit message1
+author: login1
+author_email: user@example.com
+is_cross_repository: false
+body: pr1 body
+labels: []
+requested_reviewers: []
+assignees: []
+milestone: ""
+created_at: 2020-01-01T00:00:00Z
+updated_at: 2020-01-02T00:00:00Z
+`,
+			expectedEnv: `export PR_NUMBER='1'
+export PR_TITLE='pr1 title'
+export PR_URL='pr1 url'
+export PR_HEAD_NAME='pr1'
+export PR_HEAD_SHA='oid1'
+export PR_HEAD_REPOSITORY='fork url'
+export PR_BASE_NAME='master'
+export PR_BASE_SHA='sha'
+export PR_BASE_REPOSITORY='repo1 url'
+export PR_MESSAGE='commit message1'
+export PR_AUTHOR='login1'
+export PR_AUTHOR_EMAIL='user@example.com'
+export PR_IS_CROSS_REPOSITORY='false'
+export PR_BODY='pr1 body'
+export PR_LABELS=''
+export PR_REQUESTED_REVIEWERS=''
+export PR_ASSIGNEES=''
+export PR_MILESTONE=''
+export PR_CREATED_AT='2020-01-01T00:00:00Z'
+export PR_UPDATED_AT='2020-01-02T00:00:00Z'
+`,
+		},
+		{
+			description: "get includes labels, reviewers, assignees and changed files in the metadata",
+			parameters:  resource.GetParameters{ListChangedFiles: true},
+			labels:      []string{"bug", "won't fix"},
+			reviewers:   []string{"login2", "team"},
+			assignees:   []string{"login1"},
+			milestone:   "v1.0",
+			files: []resource.ChangedFileObject{
+				{Path: "README.md"},
+				{Path: "Other.md"},
+			},
+			expectedPR: `{"number":1,"title":"pr1 title","url":"pr1 url","head":{"name":"pr1","sha":"oid1","repository":"fork url"},"base":{"name":"master","sha":"sha","repository":"repo1 url"},"message":"commit message1","author":"login1","author_email":"user@example.com","is_cross_repository":false,"body":"pr1 body","labels":["bug","won't fix"],"requested_reviewers":["login2","team"],"assignees":["login1"],"milestone":"v1.0","created_at":"2020-01-01T00:00:00Z","updated_at":"2020-01-02T00:00:00Z","changed_files":["README.md","Other.md"]}`,
+			expectedYML: `number: 1
+title: pr1 title
+url: pr1 url
+head:
+  name: pr1
+  sha: oid1
+  repository: fork url
+base:
+  name: master
+  sha: sha
+  repository: repo1 url
+message: commit message1
+author: login1
+author_email: user@example.com
+is_cross_repository: false
+body: pr1 body
+labels:
+- bug
+- won't fix
+requested_reviewers:
+- login2
+- team
+assignees:
+- login1
+milestone: v1.0
+created_at: 2020-01-01T00:00:00Z
+updated_at: 2020-01-02T00:00:00Z
+changed_files:
+- README.md
+- Other.md
+`,
+			expectedEnv: `export PR_NUMBER='1'
+export PR_TITLE='pr1 title'
+export PR_URL='pr1 url'
+export PR_HEAD_NAME='pr1'
+export PR_HEAD_SHA='oid1'
+export PR_HEAD_REPOSITORY='fork url'
+export PR_BASE_NAME='master'
+export PR_BASE_SHA='sha'
+export PR_BASE_REPOSITORY='repo1 url'
+export PR_MESSAGE='commit message1'
+export PR_AUTHOR='login1'
+export PR_AUTHOR_EMAIL='user@example.com'
+export PR_IS_CROSS_REPOSITORY='false'
+export PR_BODY='pr1 body'
+export PR_LABELS='bug,won'"'"'t fix'
+export PR_REQUESTED_REVIEWERS='login2,team'
+export PR_ASSIGNEES='login1'
+export PR_MILESTONE='v1.0'
+export PR_CREATED_AT='2020-01-01T00:00:00Z'
+export PR_UPDATED_AT='2020-01-02T00:00:00Z'
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pull := createTestPR(1, "master", false, false, 0, tc.labels)
+			pull.HeadRepository.URL = "fork url"
+			pull.RequestedReviewers = tc.reviewers
+			pull.Assignees = tc.assignees
+			pull.Milestone.Title = tc.milestone
+
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(pull, nil)
+			github.GetChangedFilesReturns(tc.files, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"},
+				Version: resource.Version{PR: "pr1", Commit: "commit1"},
+				Params:  tc.parameters,
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedPR, readTestFile(t, filepath.Join(dir, ".git", "resource", "pr.json")))
+				assert.Equal(t, tc.expectedYML, readTestFile(t, filepath.Join(dir, ".git", "resource", "pr.yml")))
+				assert.Equal(t, tc.expectedEnv, readTestFile(t, filepath.Join(dir, ".git", "resource", "env")))
+				assert.Equal(t, "pr1 body", readTestFile(t, filepath.Join(dir, ".git", "resource", "body")))
+			}
+		})
+	}
+}
+
+func TestGetChangedFiles(t *testing.T) {
+	files := []resource.ChangedFileObject{
+		{Path: "README.md", ChangeType: "modified", Additions: 2, Deletions: 1},
+		{Path: "services/foo/main.go", ChangeType: "added", Additions: 10},
+		{Path: "services/bar/main.go", ChangeType: "renamed", PreviousPath: "services/foo/old.go"},
+		{Path: "services/bar/old.go", ChangeType: "removed", Deletions: 5},
+	}
+
+	tests := []struct {
+		description   string
+		paths         []string
+		parameters    resource.GetParameters
+		expectedFiles string
+		expectedJSON  string
+	}{
+		{
+			description:   "get writes the change type and line counts of changed files",
+			paths:         []string{"services/foo"},
+			parameters:    resource.GetParameters{ListChangedFiles: true},
+			expectedFiles: "README.md\nservices/foo/main.go\nservices/bar/main.go\nservices/bar/old.go\n",
+			expectedJSON:  `[{"path":"README.md","change_type":"modified","additions":2,"deletions":1},{"path":"services/foo/main.go","change_type":"added","additions":10,"deletions":0},{"path":"services/bar/main.go","change_type":"renamed","previous_path":"services/foo/old.go","additions":0,"deletions":0},{"path":"services/bar/old.go","change_type":"removed","additions":0,"deletions":5}]`,
+		},
+		{
+			description:   "get only lists changed files that match the paths when filtered",
+			paths:         []string{"services/foo"},
+			parameters:    resource.GetParameters{ListChangedFiles: true, FilterChangedFiles: true},
+			expectedFiles: "services/foo/main.go\nservices/bar/main.go\n",
+			expectedJSON:  `[{"path":"services/foo/main.go","change_type":"added","additions":10,"deletions":0},{"path":"services/bar/main.go","change_type":"renamed","previous_path":"services/foo/old.go","additions":0,"deletions":0}]`,
+		},
+		{
+			description:   "get writes an empty list when no changed files match the paths",
+			paths:         []string{"docs"},
+			parameters:    resource.GetParameters{ListChangedFiles: true, FilterChangedFiles: true},
+			expectedFiles: "",
+			expectedJSON:  `[]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+			github.GetChangedFilesReturns(files, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken", Paths: tc.paths},
+				Version: resource.Version{PR: "pr1", Commit: "commit1"},
+				Params:  tc.parameters,
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedFiles, readTestFile(t, filepath.Join(dir, ".git", "resource", "changed_files")))
+				assert.Equal(t, tc.expectedJSON, readTestFile(t, filepath.Join(dir, ".git", "resource", "changed_files.json")))
+			}
+		})
+	}
+}
+
+func TestGetDiffAndCommits(t *testing.T) {
+	tests := []struct {
+		description     string
+		parameters      resource.GetParameters
+		expectedDiff    string
+		expectedCommits string
+		expectedPatches map[string]string
+	}{
+		{
+			description:  "get writes the diff of the pull request",
+			parameters:   resource.GetParameters{WriteDiff: true},
+			expectedDiff: "diff --git a/README.md b/README.md\n",
+		},
+		{
+			description:     "get lists the commits in the pull request",
+			parameters:      resource.GetParameters{ListCommits: true},
+			expectedCommits: `[{"sha":"oid2","message":"commit message2","author":"login2","author_email":"user@example.com","date":"2020-01-01T00:00:00Z","verified":false},{"sha":"oid1","message":"commit message1","author":"login1","author_email":"user@example.com","date":"2020-01-02T00:00:00Z","verified":true,"signer":"login1"}]`,
+		},
+		{
+			description: "get writes a patch for each commit in the pull request",
+			parameters:  resource.GetParameters{WritePatches: true},
+			expectedPatches: map[string]string{
+				"oid1.patch": "From oid1 Mon Sep 17 00:00:00 2001\n",
+				"oid2.patch": "From oid2 Mon Sep 17 00:00:00 2001\n",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pull := createTestPR(1, "master", false, false, 0, nil)
+			previous := createTestPR(2, "master", false, false, 0, nil).Tip
+			previous.CommittedDate = githubv4.DateTime{Time: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+			pull.Tip.CommittedDate = githubv4.DateTime{Time: time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)}
+			pull.Tip.Signature.IsValid = true
+			pull.Tip.Signature.Signer.Login = "login1"
+			pull.Commits = []resource.CommitObject{previous, pull.Tip}
+
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(pull, nil)
+			github.GetDiffReturns("diff --git a/README.md b/README.md\n", nil)
+			github.GetPatchStub = func(ctx context.Context, sha string) (string, error) {
+				return fmt.Sprintf("From %s Mon Sep 17 00:00:00 2001\n", sha), nil
+			}
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"},
+				Version: resource.Version{PR: "pr1", Commit: "commit1"},
+				Params:  tc.parameters,
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if tc.parameters.WriteDiff {
+				assert.Equal(t, tc.expectedDiff, readTestFile(t, filepath.Join(dir, ".git", "resource", "pr.diff")))
+				if assert.Equal(t, 1, github.GetDiffCallCount()) {
+					_, base, head := github.GetDiffArgsForCall(0)
+					assert.Equal(t, "sha", base)
+					assert.Equal(t, "oid1", head)
+				}
+			} else {
+				assert.Equal(t, 0, github.GetDiffCallCount())
+			}
+			if tc.parameters.ListCommits {
+				assert.Equal(t, tc.expectedCommits, readTestFile(t, filepath.Join(dir, ".git", "resource", "commits.json")))
+			}
+			if tc.parameters.WritePatches {
+				assert.Equal(t, len(tc.expectedPatches), github.GetPatchCallCount())
+				for name, patch := range tc.expectedPatches {
+					assert.Equal(t, patch, readTestFile(t, filepath.Join(dir, ".git", "resource", "patches", name)))
+				}
+			} else {
+				assert.Equal(t, 0, github.GetPatchCallCount())
+			}
+		})
+	}
+}
+
+func TestGetForcePushed(t *testing.T) {
+	tests := []struct {
+		description        string
+		parameters         resource.GetParameters
+		crossRepository    bool
+		getError           error
+		expectedFetchURLs  []string
+		expectedError      string
+		expectedSuperseded *resource.SupersededError
+	}{
+		{
+			description:        "get fails with a distinct error when the commit has been force-pushed away",
+			parameters:         resource.GetParameters{},
+			expectedError:      "commit oid1 has been superseded by oid2 (force-pushed), set fetch_force_pushed to build it anyway",
+			expectedSuperseded: &resource.SupersededError{Commit: "oid1", SupersededBy: "oid2"},
+		},
+		{
+			description:        "get fails with the same error when the force-pushed commit no longer exists",
+			parameters:         resource.GetParameters{FetchForcePushed: true},
+			getError:           &resource.SupersededError{Commit: "oid1", SupersededBy: "oid2", Missing: true},
+			expectedError:      "commit oid1 has been superseded by oid2 (force-pushed) and no longer exists",
+			expectedSuperseded: &resource.SupersededError{Commit: "oid1", SupersededBy: "oid2", Missing: true},
+		},
+		{
+			description:       "get fetches the commit directly when it has been force-pushed away",
+			parameters:        resource.GetParameters{FetchForcePushed: true},
+			expectedFetchURLs: []string{"repo1 url"},
+		},
+		{
+			description:       "get fetches the commit from the fork when it is not in the repository",
+			parameters:        resource.GetParameters{FetchForcePushed: true},
+			crossRepository:   true,
+			expectedFetchURLs: []string{"repo1 url", "fork url"},
+		},
+		{
+			description:   "get cannot verify the signatures of all commits when the commit has been force-pushed away",
+			parameters:    resource.GetParameters{FetchForcePushed: true, VerifySignatures: "all"},
+			expectedError: "verify_signatures: all is not supported for commit oid1, its commits are unknown since it was force-pushed",
+		},
+		{
+			description:   "get cannot check sign-offs when the commit has been force-pushed away",
+			parameters:    resource.GetParameters{FetchForcePushed: true, RequireSignOff: true},
+			expectedError: "require_signoff is not supported for commit oid1, its commits are unknown since it was force-pushed",
+		},
+		{
+			description:   "get cannot list commits when the commit has been force-pushed away",
+			parameters:    resource.GetParameters{FetchForcePushed: true, ListCommits: true},
+			expectedError: "list_commits is not supported for commit oid1, its commits are unknown since it was force-pushed",
+		},
+		{
+			description:   "get cannot write patches when the commit has been force-pushed away",
+			parameters:    resource.GetParameters{FetchForcePushed: true, WritePatches: true},
+			expectedError: "write_patches is not supported for commit oid1, its commits are unknown since it was force-pushed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pull := createTestPR(1, "master", false, false, 0, nil)
+			pull.HeadRefOID = "oid2"
+			pull.ForcePushed = true
+			pull.IsCrossRepository = tc.crossRepository
+			pull.HeadRepository.URL = "fork url"
+
+			github := new(fakes.FakeGithub)
+			if tc.getError != nil {
+				github.GetPullRequestReturns(nil, tc.getError)
+			} else {
+				github.GetPullRequestReturns(pull, nil)
+			}
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+			if tc.crossRepository {
+				git.FetchCommitReturnsOnCall(0, errors.New("fetch failed"))
+			}
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"},
+				Version: resource.Version{PR: "pr1", Commit: "oid1"},
+				Params:  tc.parameters,
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				var superseded *resource.SupersededError
+				errors.As(err, &superseded)
+				assert.Equal(t, tc.expectedSuperseded, superseded)
+				assert.Equal(t, 0, git.InitCallCount())
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, "oid2", readTestFile(t, filepath.Join(dir, ".git", "resource", "superseded_by")))
+			}
+			assert.Equal(t, 0, git.FetchCallCount())
+			if assert.Equal(t, len(tc.expectedFetchURLs), git.FetchCommitCallCount()) {
+				for i, expected := range tc.expectedFetchURLs {
+					_, url, sha, _, _, _ := git.FetchCommitArgsForCall(i)
+					assert.Equal(t, expected, url)
+					assert.Equal(t, "oid1", sha)
+				}
+			}
+			if assert.Equal(t, 1, git.MergeCallCount()) {
+				_, sha, _ := git.MergeArgsForCall(0)
+				assert.Equal(t, "oid1", sha)
+			}
+		})
+	}
+}
+
+func TestGetVerifySignatures(t *testing.T) {
+	tests := []struct {
+		description      string
+		mode             string
+		states           []githubv4.GitSignatureState
+		keyring          string
+		unverified       []string
+		expectedVerified []string
+		expectedError    string
+	}{
+		{
+			description: "get does not verify signatures by default",
+			mode:        "",
+			states:      []githubv4.GitSignatureState{"", githubv4.GitSignatureStateInvalid},
+		},
+		{
+			description: "get succeeds when the head commit has a valid signature",
+			mode:        "head",
+			states:      []githubv4.GitSignatureState{"", githubv4.GitSignatureStateValid},
+		},
+		{
+			description:   "get fails when the head commit is not signed",
+			mode:          "head",
+			states:        []githubv4.GitSignatureState{githubv4.GitSignatureStateValid, githubv4.GitSignatureStateUnsigned},
+			expectedError: "pull request has commits without a verified signature: oid1 (unsigned)",
+		},
+		{
+			description:   "get lists all commits without a valid signature",
+			mode:          "all",
+			states:        []githubv4.GitSignatureState{"", githubv4.GitSignatureStateUnknownKey},
+			expectedError: "pull request has commits without a verified signature: oid2 (unsigned), oid1 (unknown_key)",
+		},
+		{
+			description:      "get verifies the head commit against the signature keyring",
+			mode:             "head",
+			states:           []githubv4.GitSignatureState{"", githubv4.GitSignatureStateValid},
+			keyring:          "public keys",
+			expectedVerified: []string{"oid1"},
+		},
+		{
+			description:      "get lists all commits without a signature from the signature keyring",
+			mode:             "all",
+			states:           []githubv4.GitSignatureState{githubv4.GitSignatureStateValid, githubv4.GitSignatureStateValid},
+			keyring:          "public keys",
+			unverified:       []string{"oid2"},
+			expectedVerified: []string{"oid2", "oid1"},
+			expectedError:    "pull request has commits without a signature from signature_keyring: oid2 (verify-commit failed: exit status 1)",
+		},
+		{
+			description: "get does not use the signature keyring unless signatures are verified",
+			mode:        "",
+			keyring:     "public keys",
+		},
+		{
+			description:   "get fails on an invalid mode",
+			mode:          "some",
+			expectedError: "invalid signature verification specified: some",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pull := createTestPR(1, "master", false, false, 0, nil)
+			previous := createTestPR(2, "master", false, false, 0, nil).Tip
+			if len(tc.states) == 2 {
+				previous.Signature.State = tc.states[0]
+				previous.Signature.IsValid = tc.states[0] == githubv4.GitSignatureStateValid
+				pull.Tip.Signature.State = tc.states[1]
+				pull.Tip.Signature.IsValid = tc.states[1] == githubv4.GitSignatureStateValid
+			}
+			pull.Commits = []resource.CommitObject{previous, pull.Tip}
+
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(pull, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+			git.VerifyCommitStub = func(ctx context.Context, keyring, sha string) error {
+				for _, u := range tc.unverified {
+					if sha == u {
+						return errors.New("verify-commit failed: exit status 1")
+					}
+				}
+				return nil
+			}
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken", SignatureKeyring: tc.keyring},
+				Version: resource.Version{PR: "pr1", Commit: "oid1"},
+				Params:  resource.GetParameters{VerifySignatures: tc.mode},
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				// Signatures verified by Github are checked before cloning the repository.
+				if tc.keyring == "" {
+					assert.Equal(t, 0, git.InitCallCount())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var verified []string
+			for i := 0; i < git.VerifyCommitCallCount(); i++ {
+				_, keyring, sha := git.VerifyCommitArgsForCall(i)
+				assert.Equal(t, tc.keyring, keyring)
+				verified = append(verified, sha)
+			}
+			assert.Equal(t, tc.expectedVerified, verified)
+		})
+	}
+}
+
+func TestGetSignOff(t *testing.T) {
+	tests := []struct {
+		description         string
+		parameters          resource.GetParameters
+		messages            []string
+		expectedError       string
+		expectedStatus      string
+		expectedDescription string
+	}{
+		{
+			description: "get succeeds when all commits are signed off by the author",
+			parameters:  resource.GetParameters{RequireSignOff: true},
+			messages: []string{
+				"Add feature\n\nSigned-off-by: Some User <user@example.com>",
+				"Fix feature\n\nReviewed-by: Other User <other@example.com>\nsigned-off-by: Some User <USER@example.com>\n",
+			},
+		},
+		{
+			description: "get reports commits without a sign-off from the author",
+			parameters:  resource.GetParameters{RequireSignOff: true},
+			messages: []string{
+				"Add feature",
+				"Fix feature\n\nSigned-off-by: Other User <other@example.com>",
+			},
+			expectedError: "pull request has commits without a sign-off from the author: oid2 (no sign-off), oid1 (sign-off does not match author user@example.com)",
+		},
+		{
+			description: "get sets a successful status when all commits are signed off",
+			parameters:  resource.GetParameters{RequireSignOff: true, SignOffStatus: true},
+			messages: []string{
+				"Add feature\n\nSigned-off-by: Some User <user@example.com>",
+				"Fix feature\n\nSigned-off-by: Some User <user@example.com>",
+			},
+			expectedStatus:      "success",
+			expectedDescription: "All commits are signed off",
+		},
+		{
+			description: "get sets a failed status when commits are missing a sign-off",
+			parameters:  resource.GetParameters{RequireSignOff: true, SignOffStatus: true},
+			messages: []string{
+				"Add feature\n\nSigned-off-by: Some User <user@example.com>",
+				"Fix feature\n\nSigned-off-by: Some User user@example.com",
+			},
+			expectedError:       "pull request has commits without a sign-off from the author: oid1 (no sign-off)",
+			expectedStatus:      "failure",
+			expectedDescription: "1 of 2 commits are missing a sign-off",
+		},
+		{
+			description: "get does not require a sign-off for merge commits",
+			parameters:  resource.GetParameters{RequireSignOff: true, SignOffStatus: true},
+			messages: []string{
+				"Merge branch 'master' into pr1",
+				"Fix feature\n\nSigned-off-by: Some User <user@example.com>",
+			},
+			expectedStatus:      "success",
+			expectedDescription: "All commits are signed off",
+		},
+		{
+			description: "get does not check sign-offs by default",
+			parameters:  resource.GetParameters{},
+			messages:    []string{"Add feature", "Fix feature"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pull := createTestPR(1, "master", false, false, 0, nil)
+			previous := createTestPR(2, "master", false, false, 0, nil).Tip
+			previous.Message = tc.messages[0]
+			pull.Tip.Message = tc.messages[1]
+			pull.Commits = []resource.CommitObject{previous, pull.Tip}
+
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(pull, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			input := resource.GetRequest{
+				Source:  resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"},
+				Version: resource.Version{PR: "pr1", Commit: "oid1"},
+				Params:  tc.parameters,
+			}
+			_, err := resource.Get(context.TODO(), input, github, git, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.expectedStatus != "" {
+				if assert.Equal(t, 1, github.UpdateCommitStatusCallCount()) {
+					_, commit, baseContext, statusContext, status, _, description := github.UpdateCommitStatusArgsForCall(0)
+					assert.Equal(t, "oid1", commit)
+					assert.Equal(t, "", baseContext)
+					assert.Equal(t, "dco", statusContext)
+					assert.Equal(t, tc.expectedStatus, status)
+					assert.Equal(t, tc.expectedDescription, description)
+				}
+			} else {
+				assert.Equal(t, 0, github.UpdateCommitStatusCallCount())
+			}
+		})
+	}
+}
+
 func TestGetSkipDownload(t *testing.T) {
 
 	tests := []struct {
@@ -296,7 +1247,7 @@ func TestGetSkipDownload(t *testing.T) {
 
 			// Run the get and check output
 			input := resource.GetRequest{Source: tc.source, Version: tc.version, Params: tc.parameters}
-			output, err := resource.Get(input, github, git, dir)
+			output, err := resource.Get(context.TODO(), input, github, git, dir)
 
 			if assert.NoError(t, err) {
 				assert.Equal(t, tc.version, output.Version)
@@ -335,6 +1286,7 @@ func createTestPR(
 			ID:          fmt.Sprintf("pr%s", n),
 			Number:      count,
 			Title:       fmt.Sprintf("pr%s title", n),
+			Body:        fmt.Sprintf("pr%s body", n),
 			URL:         fmt.Sprintf("pr%s url", n),
 			BaseRefName: baseName,
 			HeadRefName: fmt.Sprintf("pr%s", n),
@@ -342,6 +1294,8 @@ func createTestPR(
 				URL: fmt.Sprintf("repo%s url", n),
 			},
 			IsCrossRepository: isCrossRepo,
+			CreatedAt:         githubv4.DateTime{Time: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			UpdatedAt:         githubv4.DateTime{Time: time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
 		},
 		Tip: resource.CommitObject{
 			ID:            fmt.Sprintf("commit%s", n),
@@ -358,6 +1312,11 @@ func createTestPR(
 				Email: "user@example.com",
 			},
 		},
+		BaseTip: resource.CommitObject{
+			ID:            fmt.Sprintf("basecommit%s", n),
+			OID:           fmt.Sprintf("base%s", n),
+			CommittedDate: githubv4.DateTime{Time: d.AddDate(0, 0, -1)},
+		},
 		ApprovedReviewCount: approvedCount,
 		Labels:              labelObjects,
 	}
@@ -0,0 +1,170 @@
+package resource
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxFailedTests is the number of failed tests listed in the summary.
+	maxFailedTests = 50
+	// maxFailureMessage is the number of characters shown for each failure message.
+	maxFailureMessage = 100
+)
+
+// junitTestSuite represents both <testsuites> and <testsuite> elements, which
+// can contain nested test suites and test cases respectively.
+type junitTestSuite struct {
+	Name   string           `xml:"name,attr"`
+	Suites []junitTestSuite `xml:"testsuite"`
+	Cases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure"`
+	Error     *junitResult `xml:"error"`
+	Skipped   *junitResult `xml:"skipped"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitSummary aggregates the test cases in one or more JUnit reports.
+type junitSummary struct {
+	Tests    int
+	Failures int
+	Errors   int
+	Skipped  int
+	Duration time.Duration
+	Failed   []junitFailure
+}
+
+type junitFailure struct {
+	Name     string
+	Message  string
+	Duration time.Duration
+}
+
+// readJUnitFiles parses all JUnit XML files matching the patterns (relative to dir).
+func readJUnitFiles(dir string, patterns []string) (*junitSummary, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid junit pattern %s: %s", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no junit files found matching: %s", strings.Join(patterns, ", "))
+	}
+	sort.Strings(files)
+
+	summary := &junitSummary{}
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read junit file: %s", err)
+		}
+		var suite junitTestSuite
+		if err := xml.Unmarshal(content, &suite); err != nil {
+			return nil, fmt.Errorf("failed to parse junit file %s: %s", f, err)
+		}
+		summary.add(suite)
+	}
+	return summary, nil
+}
+
+func (s *junitSummary) add(suite junitTestSuite) {
+	for _, nested := range suite.Suites {
+		s.add(nested)
+	}
+	for _, c := range suite.Cases {
+		s.Tests++
+
+		// Durations are specified in (fractional) seconds.
+		var duration time.Duration
+		if seconds, err := strconv.ParseFloat(c.Time, 64); err == nil {
+			duration = time.Duration(seconds * float64(time.Second))
+		}
+		s.Duration += duration
+
+		result := c.Failure
+		switch {
+		case c.Failure != nil:
+			s.Failures++
+		case c.Error != nil:
+			s.Errors++
+			result = c.Error
+		case c.Skipped != nil:
+			s.Skipped++
+			continue
+		default:
+			continue
+		}
+
+		name := c.Name
+		if c.ClassName != "" {
+			name = c.ClassName + "." + c.Name
+		}
+		message := result.Message
+		if message == "" {
+			message = strings.TrimSpace(result.Text)
+		}
+		s.Failed = append(s.Failed, junitFailure{Name: name, Message: message, Duration: duration})
+	}
+}
+
+// Markdown renders the summary as a comment.
+func (s *junitSummary) Markdown() string {
+	var b strings.Builder
+	b.WriteString("### Test results\n\n")
+	b.WriteString("| Tests | Passed | Failed | Errors | Skipped | Duration |\n")
+	b.WriteString("|-------|--------|--------|--------|---------|----------|\n")
+	passed := s.Tests - s.Failures - s.Errors - s.Skipped
+	fmt.Fprintf(&b, "| %d | %d | %d | %d | %d | %s |\n", s.Tests, passed, s.Failures, s.Errors, s.Skipped, formatDuration(s.Duration))
+
+	if len(s.Failed) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n#### Failed tests\n\n")
+	b.WriteString("| Test | Message | Duration |\n")
+	b.WriteString("|------|---------|----------|\n")
+	for i, f := range s.Failed {
+		if i == maxFailedTests {
+			fmt.Fprintf(&b, "\n... and %d more.\n", len(s.Failed)-maxFailedTests)
+			break
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", escapeTableCell(f.Name), escapeTableCell(truncateMessage(f.Message)), formatDuration(f.Duration))
+	}
+	return b.String()
+}
+
+// truncateMessage returns the first line of a message, shortened to maxFailureMessage characters.
+func truncateMessage(message string) string {
+	message = strings.SplitN(message, "\n", 2)[0]
+	if r := []rune(message); len(r) > maxFailureMessage {
+		return string(r[:maxFailureMessage]) + "..."
+	}
+	return message
+}
+
+func escapeTableCell(s string) string {
+	return strings.Replace(s, "|", `\|`, -1)
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
@@ -1,8 +1,12 @@
 package resource
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/shurcooL/githubv4"
@@ -23,6 +27,15 @@ type Source struct {
 	BaseBranch              string   `json:"base_branch"`
 	RequiredReviewApprovals int      `json:"required_review_approvals"`
 	Labels                  []string `json:"labels"`
+	Timeout                 string   `json:"timeout"`
+	CABundle                string   `json:"ca_bundle"`
+	ProxyURL                string   `json:"proxy_url"`
+	PrivateKey              string   `json:"private_key"`
+	KnownHosts              string   `json:"known_hosts"`
+	SkipHostKeyChecking     bool     `json:"skip_host_key_checking"`
+	TriggerOnBaseChange     bool     `json:"trigger_on_base_change"`
+	BaseChangeDebounce      string   `json:"base_change_debounce"`
+	SignatureKeyring        string   `json:"signature_keyring"`
 }
 
 // Validate the source configuration.
@@ -39,9 +52,37 @@ func (s *Source) Validate() error {
 	if s.V4Endpoint != "" && s.V3Endpoint == "" {
 		return errors.New("v3_endpoint must be set together with v4_endpoint")
 	}
+	if s.PrivateKey != "" && s.KnownHosts == "" && !s.SkipHostKeyChecking {
+		return errors.New("known_hosts or skip_host_key_checking must be set together with private_key")
+	}
+	if s.ProxyURL != "" {
+		if _, err := url.Parse(s.ProxyURL); err != nil {
+			return fmt.Errorf("failed to parse proxy_url: %s", err)
+		}
+	}
+	if s.Timeout != "" {
+		if _, err := time.ParseDuration(s.Timeout); err != nil {
+			return fmt.Errorf("failed to parse timeout: %s", err)
+		}
+	}
+	if s.BaseChangeDebounce != "" {
+		if _, err := time.ParseDuration(s.BaseChangeDebounce); err != nil {
+			return fmt.Errorf("failed to parse base_change_debounce: %s", err)
+		}
+	}
 	return nil
 }
 
+// Context returns a context which expires after the configured timeout.
+// The context is never cancelled by a deadline if no timeout is set.
+func (s *Source) Context() (context.Context, context.CancelFunc) {
+	timeout, err := time.ParseDuration(s.Timeout)
+	if s.Timeout == "" || err != nil {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
 // Metadata output from get/put steps.
 type Metadata []*MetadataField
 
@@ -56,11 +97,88 @@ type MetadataField struct {
 	Value string `json:"value"`
 }
 
+// PullRequestMetadata is the typed metadata document written by get.
+type PullRequestMetadata struct {
+	Number             int         `json:"number" yaml:"number"`
+	Title              string      `json:"title" yaml:"title"`
+	URL                string      `json:"url" yaml:"url"`
+	Head               RefMetadata `json:"head" yaml:"head"`
+	Base               RefMetadata `json:"base" yaml:"base"`
+	Message            string      `json:"message" yaml:"message"`
+	Author             string      `json:"author" yaml:"author"`
+	AuthorEmail        string      `json:"author_email" yaml:"author_email"`
+	IsCrossRepository  bool        `json:"is_cross_repository" yaml:"is_cross_repository"`
+	Body               string      `json:"body" yaml:"body"`
+	Labels             []string    `json:"labels" yaml:"labels"`
+	RequestedReviewers []string    `json:"requested_reviewers" yaml:"requested_reviewers"`
+	Assignees          []string    `json:"assignees" yaml:"assignees"`
+	Milestone          string      `json:"milestone" yaml:"milestone"`
+	CreatedAt          time.Time   `json:"created_at" yaml:"created_at"`
+	UpdatedAt          time.Time   `json:"updated_at" yaml:"updated_at"`
+	ChangedFiles       []string    `json:"changed_files,omitempty" yaml:"changed_files,omitempty"`
+	SupersededBy       string      `json:"superseded_by,omitempty" yaml:"superseded_by,omitempty"`
+}
+
+// RefMetadata describes the head or base of a pull request.
+type RefMetadata struct {
+	Name       string `json:"name" yaml:"name"`
+	SHA        string `json:"sha" yaml:"sha"`
+	Repository string `json:"repository" yaml:"repository"`
+}
+
+// Env returns the metadata as shell exports (e.g. export PR_NUMBER='1') which can be sourced by a task.
+func (m *PullRequestMetadata) Env() string {
+	variables := []struct {
+		name  string
+		value string
+	}{
+		{"PR_NUMBER", strconv.Itoa(m.Number)},
+		{"PR_TITLE", m.Title},
+		{"PR_URL", m.URL},
+		{"PR_HEAD_NAME", m.Head.Name},
+		{"PR_HEAD_SHA", m.Head.SHA},
+		{"PR_HEAD_REPOSITORY", m.Head.Repository},
+		{"PR_BASE_NAME", m.Base.Name},
+		{"PR_BASE_SHA", m.Base.SHA},
+		{"PR_BASE_REPOSITORY", m.Base.Repository},
+		{"PR_MESSAGE", m.Message},
+		{"PR_AUTHOR", m.Author},
+		{"PR_AUTHOR_EMAIL", m.AuthorEmail},
+		{"PR_IS_CROSS_REPOSITORY", strconv.FormatBool(m.IsCrossRepository)},
+		{"PR_BODY", m.Body},
+		{"PR_LABELS", strings.Join(m.Labels, ",")},
+		{"PR_REQUESTED_REVIEWERS", strings.Join(m.RequestedReviewers, ",")},
+		{"PR_ASSIGNEES", strings.Join(m.Assignees, ",")},
+		{"PR_MILESTONE", m.Milestone},
+		{"PR_CREATED_AT", m.CreatedAt.Format(time.RFC3339)},
+		{"PR_UPDATED_AT", m.UpdatedAt.Format(time.RFC3339)},
+	}
+
+	var b strings.Builder
+	for _, v := range variables {
+		// Single quote the value so that it is not expanded by the shell.
+		fmt.Fprintf(&b, "export %s='%s'\n", v.name, strings.Replace(v.value, "'", `'"'"'`, -1))
+	}
+	return b.String()
+}
+
+// CommitMetadata describes a commit in the pull request.
+type CommitMetadata struct {
+	SHA         string    `json:"sha"`
+	Message     string    `json:"message"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"author_email"`
+	Date        time.Time `json:"date"`
+	Verified    bool      `json:"verified"`
+	Signer      string    `json:"signer,omitempty"`
+}
+
 // Version communicated with Concourse.
 type Version struct {
 	PR            string    `json:"pr"`
 	Commit        string    `json:"commit"`
 	CommittedDate time.Time `json:"committed,omitempty"`
+	BaseCommit    string    `json:"base_commit,omitempty"`
 }
 
 // NewVersion constructs a new Version.
@@ -69,15 +187,23 @@ func NewVersion(p *PullRequest) Version {
 		PR:            strconv.Itoa(p.Number),
 		Commit:        p.Tip.OID,
 		CommittedDate: p.Tip.CommittedDate.Time,
+		BaseCommit:    p.BaseTip.OID,
 	}
 }
 
-// PullRequest represents a pull request and includes the tip (commit).
+// PullRequest represents a pull request and includes the tip (commit) of
+// both the pull request and its base branch.
 type PullRequest struct {
 	PullRequestObject
 	Tip                 CommitObject
+	BaseTip             CommitObject
 	ApprovedReviewCount int
 	Labels              []LabelObject
+	Commits             []CommitObject
+	RequestedReviewers  []string
+	Assignees           []string
+	Mergeable           githubv4.MergeableState
+	ForcePushed         bool
 }
 
 // PullRequestObject represents the GraphQL commit node.
@@ -86,13 +212,23 @@ type PullRequestObject struct {
 	ID          string
 	Number      int
 	Title       string
+	Body        string
 	URL         string
 	BaseRefName string
 	HeadRefName string
+	HeadRefOID  string
 	Repository  struct {
 		URL string
 	}
+	HeadRepository struct {
+		URL string
+	}
 	IsCrossRepository bool
+	Milestone         struct {
+		Title string
+	}
+	CreatedAt githubv4.DateTime
+	UpdatedAt githubv4.DateTime
 }
 
 // CommitObject represents the GraphQL commit node.
@@ -108,12 +244,23 @@ type CommitObject struct {
 		}
 		Email string
 	}
+	Signature struct {
+		IsValid bool
+		State   githubv4.GitSignatureState
+		Signer  struct {
+			Login string
+		}
+	}
 }
 
-// ChangedFileObject represents the GraphQL FilesChanged node.
-// https://developer.github.com/v4/object/pullrequestchangedfile/
+// ChangedFileObject represents a file changed in a pull request.
+// https://developer.github.com/v3/pulls/#list-pull-requests-files
 type ChangedFileObject struct {
-	Path string
+	Path         string `json:"path"`
+	ChangeType   string `json:"change_type"`
+	PreviousPath string `json:"previous_path,omitempty"`
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
 }
 
 // LabelObject represents the GraphQL label node.
@@ -0,0 +1,79 @@
+package resource_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	resource "github.com/telia-oss/github-pr-resource"
+)
+
+func TestSourceValidate(t *testing.T) {
+	tests := []struct {
+		description   string
+		source        resource.Source
+		expectedError string
+	}{
+		{
+			description: "valid source",
+			source:      resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken", Timeout: "5m"},
+		},
+		{
+			description:   "access token is required",
+			source:        resource.Source{Repository: "itsdalmo/test-repository"},
+			expectedError: "access_token must be set",
+		},
+		{
+			description:   "timeout must be a valid duration",
+			source:        resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken", Timeout: "5 minutes"},
+			expectedError: "failed to parse timeout",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.source.Validate()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestSourceContext(t *testing.T) {
+	tests := []struct {
+		description string
+		timeout     string
+		expected    time.Duration
+	}{
+		{
+			description: "context has no deadline without a timeout",
+		},
+		{
+			description: "context has a deadline with a timeout",
+			timeout:     "5m",
+			expected:    5 * time.Minute,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			source := resource.Source{Timeout: tc.timeout}
+			ctx, cancel := source.Context()
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if tc.expected == 0 {
+				assert.False(t, ok)
+				return
+			}
+			if assert.True(t, ok) {
+				assert.WithinDuration(t, time.Now().Add(tc.expected), deadline, time.Second)
+			}
+		})
+	}
+}
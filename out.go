@@ -1,16 +1,20 @@
 package resource
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"text/template"
 )
 
 // Put (business logic)
-func Put(request PutRequest, manager Github, inputDir string) (*PutResponse, error) {
+func Put(ctx context.Context, request PutRequest, manager Github, inputDir string) (*PutResponse, error) {
 	if err := request.Params.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid parameters: %s", err)
 	}
@@ -36,6 +40,35 @@ func Put(request PutRequest, manager Github, inputDir string) (*PutResponse, err
 		return nil, fmt.Errorf("failed to unmarshal metadata from file: %s", err)
 	}
 
+	// Render comments, descriptions and the target URL as templates if specified.
+	render := func(name, text string) (string, error) { return text, nil }
+	if request.Params.RenderTemplates {
+		var pr PullRequestMetadata
+		content, err = ioutil.ReadFile(filepath.Join(path, "pr.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pull request metadata from path: %s", err)
+		}
+		if err := json.Unmarshal(content, &pr); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pull request metadata from file: %s", err)
+		}
+		render = func(name, text string) (string, error) {
+			return renderTemplate(name, text, &pr)
+		}
+	}
+
+	// Variables are expanded before rendering, so that values from the pull request are inserted as written.
+	targetURL, err := render("target_url", safeExpandEnv(request.Params.TargetURL))
+	if err != nil {
+		return nil, err
+	}
+
+	// Set pending statuses if specified
+	for _, c := range request.Params.PendingContexts {
+		if err := manager.UpdateCommitStatus(ctx, version.Commit, request.Params.BaseContext, c, "pending", targetURL, ""); err != nil {
+			return nil, fmt.Errorf("failed to set pending status for %s: %s", c, err)
+		}
+	}
+
 	// Set status if specified
 	if p := request.Params; p.Status != "" {
 		description := p.Description
@@ -48,15 +81,115 @@ func Put(request PutRequest, manager Github, inputDir string) (*PutResponse, err
 			}
 			description = string(content)
 		}
+		description, err := render("description", description)
+		if err != nil {
+			return nil, err
+		}
 
-		if err := manager.UpdateCommitStatus(version.Commit, p.BaseContext, p.Context, p.Status, safeExpandEnv(p.TargetURL), description); err != nil {
+		if err := manager.UpdateCommitStatus(ctx, version.Commit, p.BaseContext, p.Context, p.Status, targetURL, description); err != nil {
 			return nil, fmt.Errorf("failed to set status: %s", err)
 		}
 	}
 
+	// Set multiple statuses if specified
+	if p := request.Params; len(p.Statuses) > 0 || p.StatusesFile != "" {
+		statuses := p.Statuses
+		if p.StatusesFile != "" {
+			content, err := ioutil.ReadFile(filepath.Join(inputDir, p.StatusesFile))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read statuses file: %s", err)
+			}
+			var fromFile []StatusParameters
+			if err := json.Unmarshal(content, &fromFile); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal statuses file: %s", err)
+			}
+			for _, s := range fromFile {
+				if err := validateStatus(s.Status); err != nil {
+					return nil, fmt.Errorf("invalid statuses file: %s", err)
+				}
+			}
+			statuses = append(statuses, fromFile...)
+		}
+
+		for _, s := range statuses {
+			description, err := render("description", s.Description)
+			if err != nil {
+				return nil, err
+			}
+			url := targetURL
+			if s.TargetURL != "" {
+				if url, err = render("target_url", safeExpandEnv(s.TargetURL)); err != nil {
+					return nil, err
+				}
+			}
+			if err := manager.UpdateCommitStatus(ctx, version.Commit, p.BaseContext, s.Context, s.Status, url, description); err != nil {
+				return nil, fmt.Errorf("failed to set status for %s: %s", s.Context, err)
+			}
+		}
+	}
+
+	// Set statuses that are still pending to error if specified
+	if p := request.Params; len(p.ErrorPendingContexts) > 0 {
+		statuses, err := manager.GetCommitStatuses(ctx, version.Commit, p.BaseContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statuses: %s", err)
+		}
+		for _, c := range p.ErrorPendingContexts {
+			if statuses[c] != "pending" {
+				continue
+			}
+			if err := manager.UpdateCommitStatus(ctx, version.Commit, p.BaseContext, c, "error", targetURL, "Concourse CI build did not complete"); err != nil {
+				return nil, fmt.Errorf("failed to set status for %s: %s", c, err)
+			}
+		}
+	}
+
+	// Create a deployment and/or set the status of the latest deployment if specified
+	if p := request.Params; p.CreateDeployment || p.DeploymentStatus != "" {
+		var id int64
+		if p.CreateDeployment {
+			id, err = manager.CreateDeployment(ctx, version.Commit, p.DeploymentEnvironment, string(p.DeploymentPayload), p.DeploymentTransient)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create deployment: %s", err)
+			}
+		} else {
+			id, err = manager.GetLatestDeployment(ctx, version.Commit, p.DeploymentEnvironment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deployment: %s", err)
+			}
+		}
+		if p.DeploymentStatus != "" {
+			environmentURL, err := render("environment_url", safeExpandEnv(p.EnvironmentURL))
+			if err != nil {
+				return nil, err
+			}
+			if err := manager.CreateDeploymentStatus(ctx, id, p.DeploymentStatus, environmentURL, targetURL); err != nil {
+				return nil, fmt.Errorf("failed to set deployment status: %s", err)
+			}
+		}
+	}
+
+	// Lint the title and/or commit messages if specified
+	if p := request.Params; p.LintTitle || p.LintCommits {
+		pull, err := manager.GetPullRequest(ctx, version.PR, version.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve pull request: %s", err)
+		}
+		if p.LintCommits && pull.ForcePushed {
+			return nil, commitsUnknownError(pull, "lint_commits")
+		}
+		status, description := "success", "Pull request passed linting"
+		if problem := lint(pull, p); problem != "" {
+			status, description = "failure", problem
+		}
+		if err := manager.UpdateCommitStatus(ctx, version.Commit, p.BaseContext, "lint", status, targetURL, description); err != nil {
+			return nil, fmt.Errorf("failed to set lint status: %s", err)
+		}
+	}
+
 	// Delete previous comments if specified
 	if request.Params.DeletePreviousComments {
-		err = manager.DeletePreviousComments(version.PR)
+		err = manager.DeletePreviousComments(ctx, version.PR)
 		if err != nil {
 			return nil, fmt.Errorf("failed to delete previous comments: %s", err)
 		}
@@ -64,7 +197,11 @@ func Put(request PutRequest, manager Github, inputDir string) (*PutResponse, err
 
 	// Set comment if specified
 	if p := request.Params; p.Comment != "" {
-		err = manager.PostComment(version.PR, safeExpandEnv(p.Comment))
+		comment, err := render("comment", safeExpandEnv(p.Comment))
+		if err != nil {
+			return nil, err
+		}
+		err = manager.PostComment(ctx, version.PR, comment)
 		if err != nil {
 			return nil, fmt.Errorf("failed to post comment: %s", err)
 		}
@@ -76,15 +213,61 @@ func Put(request PutRequest, manager Github, inputDir string) (*PutResponse, err
 		if err != nil {
 			return nil, fmt.Errorf("failed to read comment file: %s", err)
 		}
-		comment := string(content)
+		comment, err := render("comment_file", safeExpandEnv(string(content)))
+		if err != nil {
+			return nil, err
+		}
 		if comment != "" {
-			err = manager.PostComment(version.PR, safeExpandEnv(comment))
+			err = manager.PostComment(ctx, version.PR, comment)
 			if err != nil {
 				return nil, fmt.Errorf("failed to post comment: %s", err)
 			}
 		}
 	}
 
+	// Post a summary of JUnit test results if specified
+	if p := request.Params; len(p.JUnitFiles) > 0 {
+		summary, err := readJUnitFiles(inputDir, p.JUnitFiles)
+		if err != nil {
+			return nil, err
+		}
+		if err := manager.PostComment(ctx, version.PR, summary.Markdown()); err != nil {
+			return nil, fmt.Errorf("failed to post test results: %s", err)
+		}
+	}
+
+	// Report coverage of the pull request if specified
+	if p := request.Params; len(p.CoverageFiles) > 0 {
+		profile, err := readCoverageFiles(inputDir, p.CoverageFiles)
+		if err != nil {
+			return nil, err
+		}
+		var baseSHA string
+		for _, m := range metadata {
+			if m.Name == "base_sha" {
+				baseSHA = m.Value
+			}
+		}
+		diff, err := manager.GetDiff(ctx, baseSHA, version.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get diff: %s", err)
+		}
+		report, err := newCoverageReport(profile, diff)
+		if err != nil {
+			return nil, err
+		}
+
+		status, description := report.Status(p.CoverageThreshold)
+		if err := manager.UpdateCommitStatus(ctx, version.Commit, p.BaseContext, "coverage", status, targetURL, description); err != nil {
+			return nil, fmt.Errorf("failed to set coverage status: %s", err)
+		}
+		if p.CoverageComment {
+			if err := manager.PostComment(ctx, version.PR, report.Markdown()); err != nil {
+				return nil, fmt.Errorf("failed to post coverage: %s", err)
+			}
+		}
+	}
+
 	return &PutResponse{
 		Version:  version,
 		Metadata: metadata,
@@ -105,27 +288,84 @@ type PutResponse struct {
 
 // PutParameters for the resource.
 type PutParameters struct {
-	Path                   string `json:"path"`
-	BaseContext            string `json:"base_context"`
-	Context                string `json:"context"`
-	TargetURL              string `json:"target_url"`
-	DescriptionFile        string `json:"description_file"`
-	Description            string `json:"description"`
-	Status                 string `json:"status"`
-	CommentFile            string `json:"comment_file"`
-	Comment                string `json:"comment"`
-	DeletePreviousComments bool   `json:"delete_previous_comments"`
+	Path                   string             `json:"path"`
+	BaseContext            string             `json:"base_context"`
+	Context                string             `json:"context"`
+	TargetURL              string             `json:"target_url"`
+	DescriptionFile        string             `json:"description_file"`
+	Description            string             `json:"description"`
+	Status                 string             `json:"status"`
+	CommentFile            string             `json:"comment_file"`
+	Comment                string             `json:"comment"`
+	DeletePreviousComments bool               `json:"delete_previous_comments"`
+	LintTitle              bool               `json:"lint_title"`
+	LintCommits            bool               `json:"lint_commits"`
+	LintPattern            string             `json:"lint_pattern"`
+	RenderTemplates        bool               `json:"render_templates"`
+	JUnitFiles             []string           `json:"junit_files"`
+	CoverageFiles          []string           `json:"coverage_files"`
+	CoverageThreshold      float64            `json:"coverage_threshold"`
+	CoverageComment        bool               `json:"coverage_comment"`
+	Statuses               []StatusParameters `json:"statuses"`
+	StatusesFile           string             `json:"statuses_file"`
+	PendingContexts        []string           `json:"pending_contexts"`
+	ErrorPendingContexts   []string           `json:"error_pending_contexts"`
+	DeploymentEnvironment  string             `json:"deployment_environment"`
+	CreateDeployment       bool               `json:"create_deployment"`
+	DeploymentPayload      json.RawMessage    `json:"deployment_payload"`
+	DeploymentTransient    bool               `json:"deployment_transient"`
+	DeploymentStatus       string             `json:"deployment_status"`
+	EnvironmentURL         string             `json:"environment_url"`
+}
+
+// StatusParameters for setting one of multiple statuses.
+type StatusParameters struct {
+	Context     string `json:"context"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	TargetURL   string `json:"target_url"`
 }
 
 // Validate the put parameters.
 func (p *PutParameters) Validate() error {
+	if p.LintPattern != "" {
+		if _, err := regexp.Compile(p.LintPattern); err != nil {
+			return fmt.Errorf("failed to compile lint pattern: %s", err)
+		}
+	}
+	if p.CoverageThreshold < 0 || p.CoverageThreshold > 100 {
+		return fmt.Errorf("coverage threshold must be between 0 and 100: %v", p.CoverageThreshold)
+	}
+	if (p.CreateDeployment || p.DeploymentStatus != "") && p.DeploymentEnvironment == "" {
+		return fmt.Errorf("deployment_environment must be set for deployments")
+	}
+	if p.DeploymentStatus != "" {
+		var allowedStatus bool
+		for _, a := range []string{"error", "failure", "inactive", "in_progress", "queued", "pending", "success"} {
+			if strings.ToLower(p.DeploymentStatus) == a {
+				allowedStatus = true
+			}
+		}
+		if !allowedStatus {
+			return fmt.Errorf("unknown deployment status: %s", p.DeploymentStatus)
+		}
+	}
+	for _, s := range p.Statuses {
+		if err := validateStatus(s.Status); err != nil {
+			return err
+		}
+	}
 	if p.Status == "" {
 		return nil
 	}
-	// Make sure we are setting an allowed status
+	return validateStatus(p.Status)
+}
+
+// validateStatus makes sure we are setting an allowed status.
+func validateStatus(s string) error {
 	var allowedStatus bool
 
-	status := strings.ToLower(p.Status)
+	status := strings.ToLower(s)
 	allowed := []string{"success", "pending", "failure", "error"}
 
 	for _, a := range allowed {
@@ -135,12 +375,83 @@ func (p *PutParameters) Validate() error {
 	}
 
 	if !allowedStatus {
-		return fmt.Errorf("unknown status: %s", p.Status)
+		return fmt.Errorf("unknown status: %s", s)
 	}
 
 	return nil
 }
 
+// conventionalCommitPattern matches the header of a Conventional Commit: <type>[(scope)][!]: <description>
+// https://www.conventionalcommits.org/en/v1.0.0/
+var conventionalCommitPattern = regexp.MustCompile(`^[a-zA-Z]+(\([^()\r\n]+\))?!?: \S`)
+
+// maxStatusDescription is the maximum length of a commit status description on Github.
+const maxStatusDescription = 140
+
+// lint checks the title and/or commit messages (first line) of the pull request against the lint pattern
+// (or Conventional Commits if none is given), and describes the first problem found. Merge commits are ignored.
+func lint(pull *PullRequest, p PutParameters) string {
+	pattern, name := conventionalCommitPattern, "Conventional Commits"
+	if p.LintPattern != "" {
+		pattern, name = regexp.MustCompile(p.LintPattern), fmt.Sprintf("/%s/", p.LintPattern)
+	}
+
+	var problem string
+	if p.LintTitle && !pattern.MatchString(pull.Title) {
+		problem = fmt.Sprintf("Title %q does not match %s", pull.Title, name)
+	}
+	if problem == "" && p.LintCommits {
+		for _, c := range pull.Commits {
+			subject := strings.SplitN(c.Message, "\n", 2)[0]
+			if isMergeCommit(c) || pattern.MatchString(subject) {
+				continue
+			}
+			sha := c.OID
+			if len(sha) > 7 {
+				sha = sha[:7]
+			}
+			problem = fmt.Sprintf("Commit %s %q does not match %s", sha, subject, name)
+			break
+		}
+	}
+	if r := []rune(problem); len(r) > maxStatusDescription {
+		problem = string(r[:maxStatusDescription-3]) + "..."
+	}
+	return problem
+}
+
+// templateFuncs are the helpers available in templates.
+var templateFuncs = template.FuncMap{
+	// truncate shortens a string to at most n characters, e.g. {{ .Title | truncate 20 }}
+	"truncate": func(n int, s string) string {
+		if r := []rune(s); len(r) > n {
+			return string(r[:n]) + "..."
+		}
+		return s
+	},
+	// codeblock wraps a string in a Markdown code block, e.g. {{ .Body | codeblock "yaml" }}
+	"codeblock": func(language, s string) string {
+		return fmt.Sprintf("```%s\n%s\n```", language, strings.TrimRight(s, "\n"))
+	},
+	// join concatenates a list, e.g. {{ .ChangedFiles | join ", " }}
+	"join": func(sep string, s []string) string {
+		return strings.Join(s, sep)
+	},
+}
+
+// renderTemplate renders text as a Go template with the pull request metadata.
+func renderTemplate(name, text string, pr *PullRequestMetadata) (string, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %s", name, err)
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, pr); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %s", name, err)
+	}
+	return b.String(), nil
+}
+
 func safeExpandEnv(s string) string {
 	return os.Expand(s, func(v string) string {
 		switch v {
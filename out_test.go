@@ -1,8 +1,13 @@
 package resource_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -175,11 +180,11 @@ func TestPut(t *testing.T) {
 			// Run get so we have version and metadata for the put request
 			// (This is tested in in_test.go)
 			getInput := resource.GetRequest{Source: tc.source, Version: tc.version, Params: resource.GetParameters{}}
-			_, err := resource.Get(getInput, github, git, dir)
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
 			require.NoError(t, err)
 
 			putInput := resource.PutRequest{Source: tc.source, Params: tc.parameters}
-			output, err := resource.Put(putInput, github, dir)
+			output, err := resource.Put(context.TODO(), putInput, github, dir)
 
 			// Validate output
 			if assert.NoError(t, err) {
@@ -189,7 +194,7 @@ func TestPut(t *testing.T) {
 			// Validate method calls put on Github.
 			if tc.parameters.Status != "" {
 				if assert.Equal(t, 1, github.UpdateCommitStatusCallCount()) {
-					commit, baseContext, context, status, targetURL, description := github.UpdateCommitStatusArgsForCall(0)
+					_, commit, baseContext, context, status, targetURL, description := github.UpdateCommitStatusArgsForCall(0)
 					assert.Equal(t, tc.version.Commit, commit)
 					assert.Equal(t, tc.parameters.BaseContext, baseContext)
 					assert.Equal(t, tc.parameters.Context, context)
@@ -201,7 +206,7 @@ func TestPut(t *testing.T) {
 
 			if tc.parameters.Comment != "" {
 				if assert.Equal(t, 1, github.PostCommentCallCount()) {
-					pr, comment := github.PostCommentArgsForCall(0)
+					_, pr, comment := github.PostCommentArgsForCall(0)
 					assert.Equal(t, tc.version.PR, pr)
 					assert.Equal(t, tc.parameters.Comment, comment)
 				}
@@ -209,7 +214,7 @@ func TestPut(t *testing.T) {
 
 			if tc.parameters.DeletePreviousComments {
 				if assert.Equal(t, 1, github.DeletePreviousCommentsCallCount()) {
-					pr := github.DeletePreviousCommentsArgsForCall(0)
+					_, pr := github.DeletePreviousCommentsArgsForCall(0)
 					assert.Equal(t, tc.version.PR, pr)
 				}
 			}
@@ -217,6 +222,752 @@ func TestPut(t *testing.T) {
 	}
 }
 
+func TestPutLint(t *testing.T) {
+	tests := []struct {
+		description         string
+		parameters          resource.PutParameters
+		title               string
+		messages            []string
+		forcePushed         bool
+		expectedStatus      string
+		expectedDescription string
+		expectedError       string
+	}{
+		{
+			description:         "put lints the title against conventional commits",
+			parameters:          resource.PutParameters{LintTitle: true},
+			title:               "feat(api)!: add endpoint",
+			messages:            []string{"Add endpoint", "Fix tests"},
+			expectedStatus:      "success",
+			expectedDescription: "Pull request passed linting",
+		},
+		{
+			description:         "put reports a title which is not a conventional commit",
+			parameters:          resource.PutParameters{LintTitle: true},
+			title:               "Add endpoint",
+			messages:            []string{"feat: add endpoint", "fix: tests"},
+			expectedStatus:      "failure",
+			expectedDescription: `Title "Add endpoint" does not match Conventional Commits`,
+		},
+		{
+			description:         "put reports the first commit message which does not match",
+			parameters:          resource.PutParameters{LintCommits: true},
+			title:               "Add endpoint",
+			messages:            []string{"feat: add endpoint\n\nWith a body.", "Merge branch 'master' into feature", "fix tests"},
+			expectedStatus:      "failure",
+			expectedDescription: `Commit abcdef2 "fix tests" does not match Conventional Commits`,
+		},
+		{
+			description:         "put lints against a custom pattern",
+			parameters:          resource.PutParameters{LintTitle: true, LintCommits: true, LintPattern: `^[A-Z]+-[0-9]+ `},
+			title:               "ABC-123 Add endpoint",
+			messages:            []string{"ABC-123 Add endpoint", "fix tests"},
+			expectedStatus:      "failure",
+			expectedDescription: `Commit abcdef1 "fix tests" does not match /^[A-Z]+-[0-9]+ /`,
+		},
+		{
+			description:         "put truncates long descriptions",
+			parameters:          resource.PutParameters{LintTitle: true},
+			title:               strings.Repeat("a", 140),
+			expectedStatus:      "failure",
+			expectedDescription: `Title "` + strings.Repeat("a", 130) + "...",
+		},
+		{
+			description:   "put cannot lint the commits of a force-pushed commit",
+			parameters:    resource.PutParameters{LintCommits: true},
+			title:         "feat: add endpoint",
+			forcePushed:   true,
+			expectedError: "lint_commits is not supported for commit oid1, its commits are unknown since it was force-pushed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pull := createTestPR(1, "master", false, false, 0, nil)
+			pull.Title = tc.title
+			for i, message := range tc.messages {
+				commit := createTestPR(i+2, "master", false, false, 0, nil).Tip
+				commit.OID = fmt.Sprintf("abcdef%d0000", i)
+				commit.Message = message
+				pull.Commits = append(pull.Commits, commit)
+			}
+			pull.ForcePushed = tc.forcePushed
+
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(pull, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			source := resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"}
+			version := resource.Version{PR: "pr1", Commit: "oid1"}
+
+			// Run get so we have version and metadata for the put request
+			getInput := resource.GetRequest{Source: source, Version: version, Params: resource.GetParameters{FetchForcePushed: tc.forcePushed}}
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
+			require.NoError(t, err)
+
+			putInput := resource.PutRequest{Source: source, Params: tc.parameters}
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				assert.Equal(t, 0, github.UpdateCommitStatusCallCount())
+				return
+			}
+			require.NoError(t, err)
+
+			if assert.Equal(t, 1, github.UpdateCommitStatusCallCount()) {
+				_, commit, _, statusContext, status, _, description := github.UpdateCommitStatusArgsForCall(0)
+				assert.Equal(t, "oid1", commit)
+				assert.Equal(t, "lint", statusContext)
+				assert.Equal(t, tc.expectedStatus, status)
+				assert.Equal(t, tc.expectedDescription, description)
+			}
+		})
+	}
+}
+
+func TestPutTemplates(t *testing.T) {
+	tests := []struct {
+		description         string
+		parameters          resource.PutParameters
+		expectedComment     string
+		expectedDescription string
+		expectedTargetURL   string
+		expectedError       string
+	}{
+		{
+			description: "put renders templates with the pull request metadata",
+			parameters: resource.PutParameters{
+				Status:          "success",
+				Description:     "Built {{ .Head.SHA }} on {{ .Base.Name }}",
+				TargetURL:       "https://example.com/pr/{{ .Number }}",
+				Comment:         "{{ .Title | truncate 3 }} by {{ .Author }}\n{{ .ChangedFiles | join \"\\n\" | codeblock \"\" }}",
+				RenderTemplates: true,
+			},
+			expectedComment:     "pr1... by login1\n```\nREADME.md\nOther.md\n```",
+			expectedDescription: "Built oid1 on master",
+			expectedTargetURL:   "https://example.com/pr/1",
+		},
+		{
+			description: "put does not render templates unless enabled",
+			parameters: resource.PutParameters{
+				Status:      "success",
+				Description: "Built {{ .Head.SHA }}",
+				Comment:     "{{ .Title }}",
+			},
+			expectedComment:     "{{ .Title }}",
+			expectedDescription: "Built {{ .Head.SHA }}",
+		},
+		{
+			description: "put fails on invalid templates",
+			parameters: resource.PutParameters{
+				Comment:         "{{ .Unknown }}",
+				RenderTemplates: true,
+			},
+			expectedError: `failed to render comment template: template: comment:1:3: executing "comment" at <.Unknown>: can't evaluate field Unknown in type *resource.PullRequestMetadata`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+			github.GetChangedFilesReturns([]resource.ChangedFileObject{{Path: "README.md"}, {Path: "Other.md"}}, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			source := resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"}
+			version := resource.Version{PR: "pr1", Commit: "commit1"}
+
+			// Run get so we have version and metadata for the put request
+			getInput := resource.GetRequest{Source: source, Version: version, Params: resource.GetParameters{ListChangedFiles: true}}
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
+			require.NoError(t, err)
+
+			putInput := resource.PutRequest{Source: source, Params: tc.parameters}
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			if assert.Equal(t, 1, github.PostCommentCallCount()) {
+				_, _, comment := github.PostCommentArgsForCall(0)
+				assert.Equal(t, tc.expectedComment, comment)
+			}
+			if assert.Equal(t, 1, github.UpdateCommitStatusCallCount()) {
+				_, _, _, _, _, targetURL, description := github.UpdateCommitStatusArgsForCall(0)
+				assert.Equal(t, tc.expectedTargetURL, targetURL)
+				assert.Equal(t, tc.expectedDescription, description)
+			}
+		})
+	}
+}
+
+func TestPutJUnit(t *testing.T) {
+	tests := []struct {
+		description     string
+		files           map[string]string
+		parameters      resource.PutParameters
+		expectedComment string
+		expectedError   string
+	}{
+		{
+			description: "put summarises junit reports",
+			files: map[string]string{
+				"reports/unit.xml": `<testsuites>
+  <testsuite name="unit">
+    <testcase classname="pkg" name="TestPass" time="0.5"/>
+    <testcase classname="pkg" name="TestFail" time="1.25"><failure message="expected 1 | got 2">stack</failure></testcase>
+    <testcase classname="pkg" name="TestSkip" time="0"><skipped/></testcase>
+  </testsuite>
+</testsuites>`,
+				"reports/e2e.xml": `<testsuite name="e2e">
+  <testcase name="TestError" time="2"><error>panic: boom
+goroutine 1</error></testcase>
+</testsuite>`,
+			},
+			parameters: resource.PutParameters{JUnitFiles: []string{"reports/*.xml"}},
+			expectedComment: "### Test results\n\n" +
+				"| Tests | Passed | Failed | Errors | Skipped | Duration |\n" +
+				"|-------|--------|--------|--------|---------|----------|\n" +
+				"| 4 | 1 | 1 | 1 | 1 | 3.75s |\n\n" +
+				"#### Failed tests\n\n" +
+				"| Test | Message | Duration |\n" +
+				"|------|---------|----------|\n" +
+				"| `TestError` | panic: boom | 2.00s |\n" +
+				"| `pkg.TestFail` | expected 1 \\| got 2 | 1.25s |\n",
+		},
+		{
+			description:   "put fails when no junit reports match",
+			parameters:    resource.PutParameters{JUnitFiles: []string{"reports/*.xml"}},
+			expectedError: "no junit files found matching: reports/*.xml",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			source := resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"}
+			version := resource.Version{PR: "pr1", Commit: "commit1"}
+
+			// Run get so we have version and metadata for the put request
+			getInput := resource.GetRequest{Source: source, Version: version, Params: resource.GetParameters{}}
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
+			require.NoError(t, err)
+
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+				require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+			}
+
+			putInput := resource.PutRequest{Source: source, Params: tc.parameters}
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			if assert.Equal(t, 1, github.PostCommentCallCount()) {
+				_, pr, comment := github.PostCommentArgsForCall(0)
+				assert.Equal(t, "pr1", pr)
+				assert.Equal(t, tc.expectedComment, comment)
+			}
+		})
+	}
+}
+
+func TestPutCoverage(t *testing.T) {
+	diff := `diff --git a/pkg/main.go b/pkg/main.go
+--- a/pkg/main.go
++++ b/pkg/main.go
+@@ -1,2 +1,5 @@
+ package main
++func a() {}
++++ counter
++func b() {}
+ // end
+diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-old
++new
+`
+
+	var (
+		goProfile = `mode: set
+github.com/itsdalmo/test-repository/pkg/main.go:2.10,2.12 1 1
+github.com/itsdalmo/test-repository/pkg/main.go:3.10,3.12 1 0
+github.com/itsdalmo/test-repository/pkg/other.go:1.1,2.2 1 1
+`
+		coberturaReport = `<?xml version="1.0" ?>
+<coverage>
+  <packages>
+    <package name="pkg">
+      <classes>
+        <class filename="pkg/main.go"><lines><line number="2" hits="3"/><line number="3" hits="0"/></lines></class>
+        <class filename="pkg/other.go"><lines><line number="1" hits="1"/><line number="2" hits="1"/></lines></class>
+      </classes>
+    </package>
+  </packages>
+</coverage>
+`
+		lcovReport = `TN:
+SF:/tmp/build/pkg/main.go
+DA:2,1
+DA:3,0
+end_of_record
+SF:/tmp/build/pkg/other.go
+DA:1,1
+DA:2,1
+end_of_record
+`
+		expectedComment = "### Coverage\n\n" +
+			"| File | Coverage | Patch coverage |\n" +
+			"|------|----------|----------------|\n" +
+			"| `pkg/main.go` | 50.00% (1/2) | 50.00% (1/2) |\n" +
+			"| **Total** | 75.00% (3/4) | 50.00% (1/2) |\n"
+	)
+
+	tests := []struct {
+		description         string
+		files               map[string]string
+		diff                string
+		parameters          resource.PutParameters
+		expectedStatus      string
+		expectedDescription string
+		expectedComment     string
+		expectedError       string
+	}{
+		{
+			description:         "put reports coverage from go cover profiles",
+			files:               map[string]string{"coverage/cover.out": goProfile},
+			parameters:          resource.PutParameters{CoverageFiles: []string{"coverage/*"}, CoverageComment: true},
+			expectedStatus:      "success",
+			expectedDescription: "Total 75.00%, patch 50.00%",
+			expectedComment:     expectedComment,
+		},
+		{
+			description:         "put reports coverage from cobertura reports",
+			files:               map[string]string{"coverage/coverage.xml": coberturaReport},
+			parameters:          resource.PutParameters{CoverageFiles: []string{"coverage/*"}, CoverageComment: true, CoverageThreshold: 50},
+			expectedStatus:      "success",
+			expectedDescription: "Total 75.00%, patch 50.00% (threshold 50.00%)",
+			expectedComment:     expectedComment,
+		},
+		{
+			description:         "put fails the status when patch coverage is below the threshold",
+			files:               map[string]string{"coverage/lcov.info": lcovReport},
+			parameters:          resource.PutParameters{CoverageFiles: []string{"coverage/*"}, CoverageThreshold: 80},
+			expectedStatus:      "failure",
+			expectedDescription: "Total 75.00%, patch 50.00% (threshold 80.00%)",
+		},
+		{
+			description: "put matches the shortest path when several files share a suffix",
+			files: map[string]string{"coverage/cover.out": `mode: set
+github.com/itsdalmo/test-repository/cmd/pkg/main.go:2.10,2.12 1 1
+github.com/itsdalmo/test-repository/cmd/pkg/main.go:3.10,3.12 1 1
+github.com/itsdalmo/test-repository/pkg/main.go:2.10,2.12 1 1
+github.com/itsdalmo/test-repository/pkg/main.go:3.10,3.12 1 0
+`},
+			parameters:          resource.PutParameters{CoverageFiles: []string{"coverage/*"}},
+			expectedStatus:      "success",
+			expectedDescription: "Total 75.00%, patch 50.00%",
+		},
+		{
+			description:   "put fails when the diff cannot be read",
+			files:         map[string]string{"coverage/cover.out": goProfile},
+			diff:          "+++ b/pkg/main.go\n@@ -0,0 +1 @@\n+" + strings.Repeat("a", 2*1024*1024) + "\n",
+			parameters:    resource.PutParameters{CoverageFiles: []string{"coverage/*"}},
+			expectedError: "failed to read diff: bufio.Scanner: token too long",
+		},
+		{
+			description:   "put fails when no coverage files match",
+			parameters:    resource.PutParameters{CoverageFiles: []string{"coverage/*"}},
+			expectedError: "no coverage files found matching: coverage/*",
+		},
+		{
+			description:   "put fails on invalid thresholds",
+			parameters:    resource.PutParameters{CoverageFiles: []string{"coverage/*"}, CoverageThreshold: 101},
+			expectedError: "invalid parameters: coverage threshold must be between 0 and 100: 101",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+			if tc.diff != "" {
+				github.GetDiffReturns(tc.diff, nil)
+			} else {
+				github.GetDiffReturns(diff, nil)
+			}
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			source := resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"}
+			version := resource.Version{PR: "pr1", Commit: "commit1"}
+
+			// Run get so we have version and metadata for the put request
+			getInput := resource.GetRequest{Source: source, Version: version, Params: resource.GetParameters{}}
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
+			require.NoError(t, err)
+
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+				require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+			}
+
+			putInput := resource.PutRequest{Source: source, Params: tc.parameters}
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			if assert.Equal(t, 1, github.GetDiffCallCount()) {
+				_, base, head := github.GetDiffArgsForCall(0)
+				assert.Equal(t, "sha", base)
+				assert.Equal(t, "commit1", head)
+			}
+			if assert.Equal(t, 1, github.UpdateCommitStatusCallCount()) {
+				_, commit, _, context, status, _, description := github.UpdateCommitStatusArgsForCall(0)
+				assert.Equal(t, "commit1", commit)
+				assert.Equal(t, "coverage", context)
+				assert.Equal(t, tc.expectedStatus, status)
+				assert.Equal(t, tc.expectedDescription, description)
+			}
+			if tc.expectedComment != "" {
+				if assert.Equal(t, 1, github.PostCommentCallCount()) {
+					_, _, comment := github.PostCommentArgsForCall(0)
+					assert.Equal(t, tc.expectedComment, comment)
+				}
+			} else {
+				assert.Equal(t, 0, github.PostCommentCallCount())
+			}
+		})
+	}
+}
+
+func TestPutStatuses(t *testing.T) {
+	type commitStatus struct {
+		context, status, targetURL, description string
+	}
+
+	tests := []struct {
+		description      string
+		parameters       resource.PutParameters
+		statusesFile     string
+		expectedStatuses []commitStatus
+		expectedError    string
+	}{
+		{
+			description: "put sets multiple statuses",
+			parameters: resource.PutParameters{
+				TargetURL: "https://example.com/build",
+				Statuses: []resource.StatusParameters{
+					{Context: "build/linux", Status: "success", Description: "Linux build passed"},
+					{Context: "build/arm", Status: "failure", TargetURL: "https://example.com/arm"},
+				},
+			},
+			expectedStatuses: []commitStatus{
+				{"build/linux", "success", "https://example.com/build", "Linux build passed"},
+				{"build/arm", "failure", "https://example.com/arm", ""},
+			},
+		},
+		{
+			description: "put sets statuses from a file",
+			parameters: resource.PutParameters{
+				Statuses:     []resource.StatusParameters{{Context: "build", Status: "success"}},
+				StatusesFile: "statuses.json",
+			},
+			statusesFile: `[{"context": "lint", "status": "error", "description": "Lint crashed"}]`,
+			expectedStatuses: []commitStatus{
+				{"build", "success", "", ""},
+				{"lint", "error", "", "Lint crashed"},
+			},
+		},
+		{
+			description: "put fails on unknown statuses",
+			parameters: resource.PutParameters{
+				Statuses: []resource.StatusParameters{{Context: "build", Status: "done"}},
+			},
+			expectedError: "invalid parameters: unknown status: done",
+		},
+		{
+			description:   "put fails on unknown statuses in a file",
+			parameters:    resource.PutParameters{StatusesFile: "statuses.json"},
+			statusesFile:  `[{"context": "build", "status": "done"}]`,
+			expectedError: "invalid statuses file: unknown status: done",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			source := resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"}
+			version := resource.Version{PR: "pr1", Commit: "commit1"}
+
+			// Run get so we have version and metadata for the put request
+			getInput := resource.GetRequest{Source: source, Version: version, Params: resource.GetParameters{}}
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
+			require.NoError(t, err)
+
+			if tc.statusesFile != "" {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "statuses.json"), []byte(tc.statusesFile), 0644))
+			}
+
+			putInput := resource.PutRequest{Source: source, Params: tc.parameters}
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				assert.Equal(t, 0, github.UpdateCommitStatusCallCount())
+				return
+			}
+			require.NoError(t, err)
+
+			if assert.Equal(t, len(tc.expectedStatuses), github.UpdateCommitStatusCallCount()) {
+				for i, expected := range tc.expectedStatuses {
+					_, commit, _, context, status, targetURL, description := github.UpdateCommitStatusArgsForCall(i)
+					assert.Equal(t, "commit1", commit)
+					assert.Equal(t, expected, commitStatus{context: context, status: status, targetURL: targetURL, description: description})
+				}
+			}
+		})
+	}
+}
+
+func TestPutPendingStatuses(t *testing.T) {
+	tests := []struct {
+		description      string
+		parameters       resource.PutParameters
+		currentStatuses  map[string]string
+		expectedStatuses map[string]string
+	}{
+		{
+			description:      "put sets pending statuses",
+			parameters:       resource.PutParameters{PendingContexts: []string{"build", "lint"}},
+			expectedStatuses: map[string]string{"build": "pending", "lint": "pending"},
+		},
+		{
+			description:      "put sets statuses that are still pending to error",
+			parameters:       resource.PutParameters{ErrorPendingContexts: []string{"build", "lint", "test"}},
+			currentStatuses:  map[string]string{"build": "success", "lint": "pending", "other": "pending"},
+			expectedStatuses: map[string]string{"lint": "error"},
+		},
+		{
+			description: "put sets final statuses before checking for pending statuses",
+			parameters: resource.PutParameters{
+				Statuses:             []resource.StatusParameters{{Context: "build", Status: "success"}},
+				ErrorPendingContexts: []string{"lint"},
+			},
+			currentStatuses:  map[string]string{"lint": "pending"},
+			expectedStatuses: map[string]string{"build": "success", "lint": "error"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+			github.GetCommitStatusesReturns(tc.currentStatuses, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			source := resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"}
+			version := resource.Version{PR: "pr1", Commit: "commit1"}
+
+			// Run get so we have version and metadata for the put request
+			getInput := resource.GetRequest{Source: source, Version: version, Params: resource.GetParameters{}}
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
+			require.NoError(t, err)
+
+			putInput := resource.PutRequest{Source: source, Params: tc.parameters}
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
+			require.NoError(t, err)
+
+			if len(tc.parameters.ErrorPendingContexts) > 0 && assert.Equal(t, 1, github.GetCommitStatusesCallCount()) {
+				_, commit, _ := github.GetCommitStatusesArgsForCall(0)
+				assert.Equal(t, "commit1", commit)
+			}
+
+			statuses := make(map[string]string)
+			for i := 0; i < github.UpdateCommitStatusCallCount(); i++ {
+				_, commit, _, context, status, _, _ := github.UpdateCommitStatusArgsForCall(i)
+				assert.Equal(t, "commit1", commit)
+				statuses[context] = status
+			}
+			assert.Equal(t, tc.expectedStatuses, statuses)
+		})
+	}
+}
+
+func TestPutDeployments(t *testing.T) {
+	tests := []struct {
+		description       string
+		parameters        resource.PutParameters
+		expectCreate      bool
+		expectedStatus    string
+		expectedEnvURL    string
+		expectedError     string
+		expectedPayload   string
+		expectedTransient bool
+	}{
+		{
+			description: "put creates a deployment with a status",
+			parameters: resource.PutParameters{
+				DeploymentEnvironment: "preview-1",
+				CreateDeployment:      true,
+				DeploymentPayload:     json.RawMessage(`{"replicas":1}`),
+				DeploymentTransient:   true,
+				DeploymentStatus:      "in_progress",
+				TargetURL:             "https://example.com/build",
+			},
+			expectCreate:      true,
+			expectedStatus:    "in_progress",
+			expectedPayload:   `{"replicas":1}`,
+			expectedTransient: true,
+		},
+		{
+			description: "put sets the status of the latest deployment",
+			parameters: resource.PutParameters{
+				DeploymentEnvironment: "preview-1",
+				DeploymentStatus:      "success",
+				EnvironmentURL:        "https://preview-{{ .Number }}.example.com",
+				TargetURL:             "https://example.com/build",
+				RenderTemplates:       true,
+			},
+			expectedStatus: "success",
+			expectedEnvURL: "https://preview-1.example.com",
+		},
+		{
+			description:   "put requires an environment for deployments",
+			parameters:    resource.PutParameters{CreateDeployment: true},
+			expectedError: "invalid parameters: deployment_environment must be set for deployments",
+		},
+		{
+			description:   "put fails on unknown deployment statuses",
+			parameters:    resource.PutParameters{DeploymentEnvironment: "preview-1", DeploymentStatus: "done"},
+			expectedError: "invalid parameters: unknown deployment status: done",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			github := new(fakes.FakeGithub)
+			github.GetPullRequestReturns(createTestPR(1, "master", false, false, 0, nil), nil)
+			github.CreateDeploymentReturns(1, nil)
+			github.GetLatestDeploymentReturns(2, nil)
+
+			git := new(fakes.FakeGit)
+			git.RevParseReturns("sha", nil)
+
+			dir := createTestDirectory(t)
+			defer os.RemoveAll(dir)
+
+			source := resource.Source{Repository: "itsdalmo/test-repository", AccessToken: "oauthtoken"}
+			version := resource.Version{PR: "pr1", Commit: "commit1"}
+
+			// Run get so we have version and metadata for the put request
+			getInput := resource.GetRequest{Source: source, Version: version, Params: resource.GetParameters{}}
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
+			require.NoError(t, err)
+
+			putInput := resource.PutRequest{Source: source, Params: tc.parameters}
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
+
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.expectedError, err.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			expectedID := int64(2)
+			if tc.expectCreate {
+				expectedID = 1
+				if assert.Equal(t, 1, github.CreateDeploymentCallCount()) {
+					_, commit, environment, payload, transient := github.CreateDeploymentArgsForCall(0)
+					assert.Equal(t, "commit1", commit)
+					assert.Equal(t, "preview-1", environment)
+					assert.Equal(t, tc.expectedPayload, payload)
+					assert.Equal(t, tc.expectedTransient, transient)
+				}
+				assert.Equal(t, 0, github.GetLatestDeploymentCallCount())
+			} else {
+				assert.Equal(t, 0, github.CreateDeploymentCallCount())
+				if assert.Equal(t, 1, github.GetLatestDeploymentCallCount()) {
+					_, commit, environment := github.GetLatestDeploymentArgsForCall(0)
+					assert.Equal(t, "commit1", commit)
+					assert.Equal(t, "preview-1", environment)
+				}
+			}
+
+			if assert.Equal(t, 1, github.CreateDeploymentStatusCallCount()) {
+				_, id, status, environmentURL, logURL := github.CreateDeploymentStatusArgsForCall(0)
+				assert.Equal(t, expectedID, id)
+				assert.Equal(t, tc.expectedStatus, status)
+				assert.Equal(t, tc.expectedEnvURL, environmentURL)
+				assert.Equal(t, "https://example.com/build", logURL)
+			}
+		})
+	}
+}
+
 func TestVariableSubstitution(t *testing.T) {
 
 	var (
@@ -289,6 +1040,29 @@ func TestVariableSubstitution(t *testing.T) {
 			expectedComment: "$THIS_IS_NOT_SUBSTITUTED",
 			pullRequest:     createTestPR(1, "master", false, false, 0, nil),
 		},
+
+		{
+			description: "we do not substitute variables in the pull request metadata",
+			source: resource.Source{
+				Repository:  "itsdalmo/test-repository",
+				AccessToken: "oauthtoken",
+			},
+			version: resource.Version{
+				PR:            "pr1",
+				Commit:        "commit1",
+				CommittedDate: time.Time{},
+			},
+			parameters: resource.PutParameters{
+				Comment:         fmt.Sprintf("{{ .Title }} ($%s)", variableName),
+				RenderTemplates: true,
+			},
+			expectedComment: fmt.Sprintf("Print $%s and ${HOME} (%s)", variableName, variableValue),
+			pullRequest: func() *resource.PullRequest {
+				pr := createTestPR(1, "master", false, false, 0, nil)
+				pr.Title = fmt.Sprintf("Print $%s and ${HOME}", variableName)
+				return pr
+			}(),
+		},
 	}
 
 	for _, tc := range tests {
@@ -304,7 +1078,7 @@ func TestVariableSubstitution(t *testing.T) {
 
 			// Run get so we have version and metadata for the put request
 			getInput := resource.GetRequest{Source: tc.source, Version: tc.version, Params: resource.GetParameters{}}
-			_, err := resource.Get(getInput, github, git, dir)
+			_, err := resource.Get(context.TODO(), getInput, github, git, dir)
 			require.NoError(t, err)
 
 			oldValue := os.Getenv(variableName)
@@ -313,18 +1087,18 @@ func TestVariableSubstitution(t *testing.T) {
 			os.Setenv(variableName, variableValue)
 
 			putInput := resource.PutRequest{Source: tc.source, Params: tc.parameters}
-			_, err = resource.Put(putInput, github, dir)
+			_, err = resource.Put(context.TODO(), putInput, github, dir)
 
 			if tc.parameters.TargetURL != "" {
 				if assert.Equal(t, 1, github.UpdateCommitStatusCallCount()) {
-					_, _, _, _, targetURL, _ := github.UpdateCommitStatusArgsForCall(0)
+					_, _, _, _, _, targetURL, _ := github.UpdateCommitStatusArgsForCall(0)
 					assert.Equal(t, tc.expectedTargetURL, targetURL)
 				}
 			}
 
 			if tc.parameters.Comment != "" {
 				if assert.Equal(t, 1, github.PostCommentCallCount()) {
-					_, comment := github.PostCommentArgsForCall(0)
+					_, _, comment := github.PostCommentArgsForCall(0)
 					assert.Equal(t, tc.expectedComment, comment)
 				}
 			}